@@ -0,0 +1,176 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// FunctionCall describes a single call to a function
+type FunctionCall struct {
+	FunctionName string
+	Event        Event
+}
+
+// CallResult holds the outcome of a function call
+type CallResult struct {
+	Response Response
+	Err      error
+}
+
+// CallError is the error of a single call made as part of a fan-out
+type CallError struct {
+	Index        int
+	FunctionName string
+	Err          error
+}
+
+// Error returns the error message
+func (ce *CallError) Error() string {
+	return fmt.Sprintf("Call #%d to function %s failed: %s", ce.Index, ce.FunctionName, ce.Err.Error())
+}
+
+// Unwrap returns the error of the call
+func (ce *CallError) Unwrap() error {
+	return ce.Err
+}
+
+// CallErrors aggregates the errors of all failed calls made as part of a fan-out, ordered by call index
+type CallErrors []*CallError
+
+// Error returns the error messages of all failed calls
+func (ce CallErrors) Error() string {
+	messages := make([]string, 0, len(ce))
+	for _, callError := range ce {
+		messages = append(messages, callError.Error())
+	}
+
+	return fmt.Sprintf("%d function calls failed: %s", len(ce), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of all failed calls
+func (ce CallErrors) Unwrap() []error {
+	errs := make([]error, 0, len(ce))
+	for _, callError := range ce {
+		errs = append(errs, callError)
+	}
+
+	return errs
+}
+
+// Is checks if any of the failed calls failed with the target error. It lets errors.Is look into the
+// errors of the calls on Go versions which don't follow Unwrap() []error
+func (ce CallErrors) Is(target error) bool {
+	for _, callError := range ce {
+		if errors.Is(callError, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first error of the failed calls that matches the target, like errors.As
+func (ce CallErrors) As(target interface{}) bool {
+	for _, callError := range ce {
+		if errors.As(callError, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CallFunctionAsync calls a function in the background. The result is delivered on the returned
+// channel, which is closed afterwards
+func (p *Platform) CallFunctionAsync(ctx context.Context, functionName string, event Event) <-chan CallResult {
+	resultChan := make(chan CallResult, 1)
+
+	go func() {
+		defer close(resultChan)
+
+		response, err := p.CallFunctionWithContext(ctx, functionName, event)
+		resultChan <- CallResult{Response: response, Err: err}
+	}()
+
+	return resultChan
+}
+
+// CallFunctions calls multiple functions concurrently, running at most maxConcurrency calls at a
+// time (zero or less means no limit). Results are returned in the order of the calls. If any call
+// failed, the returned error is a CallErrors holding all failures
+func (p *Platform) CallFunctions(ctx context.Context, calls []FunctionCall, maxConcurrency int) ([]CallResult, error) {
+	results := make([]CallResult, len(calls))
+
+	if maxConcurrency <= 0 || maxConcurrency > len(calls) {
+		maxConcurrency = len(calls)
+	}
+
+	semaphore := make(chan struct{}, maxConcurrency)
+	waitGroup := sync.WaitGroup{}
+
+	for callIndex, call := range calls {
+		waitGroup.Add(1)
+		semaphore <- struct{}{}
+
+		go func(callIndex int, call FunctionCall) {
+			defer func() {
+				<-semaphore
+				waitGroup.Done()
+			}()
+
+			response, err := p.CallFunctionWithContext(ctx, call.FunctionName, call.Event)
+			results[callIndex] = CallResult{Response: response, Err: err}
+		}(callIndex, call)
+	}
+
+	waitGroup.Wait()
+
+	var callErrors CallErrors
+	for callIndex, result := range results {
+		if result.Err != nil {
+			callErrors = append(callErrors, &CallError{
+				Index:        callIndex,
+				FunctionName: calls[callIndex].FunctionName,
+				Err:          result.Err,
+			})
+		}
+	}
+
+	if len(callErrors) > 0 {
+		return results, callErrors
+	}
+
+	return results, nil
+}
+
+// CallFunctionWithEvents calls a single function with multiple events concurrently. See CallFunctions
+func (p *Platform) CallFunctionWithEvents(ctx context.Context,
+	functionName string,
+	events []Event,
+	maxConcurrency int) ([]CallResult, error) {
+	calls := make([]FunctionCall, 0, len(events))
+	for _, event := range events {
+		calls = append(calls, FunctionCall{FunctionName: functionName, Event: event})
+	}
+
+	return p.CallFunctions(ctx, calls, maxConcurrency)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+func newFanOutPlatform(t *testing.T, handler fasthttp.RequestHandler) *Platform {
+	return newTestPlatform(t, &PlatformConfiguration{
+		FunctionResolver: StaticFunctionResolver{"echo": startFunctionServer(t, handler)},
+	})
+}
+
+func TestCallFunctionAsync(t *testing.T) {
+	platform := newFanOutPlatform(t, func(ctx *fasthttp.RequestCtx) {
+		ctx.SetBody(ctx.PostBody())
+	})
+
+	result := <-platform.CallFunctionAsync(context.Background(), "echo", &MemoryEvent{Body: []byte("hello")})
+	if result.Err != nil || string(result.Response.Body) != "hello" {
+		t.Fatalf("Bad async result: %s, %v", result.Response.Body, result.Err)
+	}
+}
+
+func TestCallFunctionsOrderAndConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	platform := newFanOutPlatform(t, func(ctx *fasthttp.RequestCtx) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+
+		// later calls finish first, so that results arrive out of order
+		index, _ := strconv.Atoi(string(ctx.PostBody()))
+		time.Sleep(time.Duration(10-index) * 5 * time.Millisecond)
+
+		ctx.SetBody(ctx.PostBody())
+	})
+
+	var events []Event
+	for eventIndex := 0; eventIndex < 10; eventIndex++ {
+		events = append(events, &MemoryEvent{Body: []byte(strconv.Itoa(eventIndex))})
+	}
+
+	results, err := platform.CallFunctionWithEvents(context.Background(), "echo", events, 3)
+	if err != nil {
+		t.Fatalf("Calls failed: %s", err)
+	}
+
+	for resultIndex, result := range results {
+		if string(result.Response.Body) != strconv.Itoa(resultIndex) {
+			t.Fatalf("Result %d is out of order: %s", resultIndex, result.Response.Body)
+		}
+	}
+
+	if maxInFlight := atomic.LoadInt32(&maxInFlight); maxInFlight > 3 || maxInFlight < 2 {
+		t.Fatalf("Expected at most 3 concurrent calls, got %d", maxInFlight)
+	}
+}
+
+func TestCallFunctionsErrors(t *testing.T) {
+	platform := newFanOutPlatform(t, func(ctx *fasthttp.RequestCtx) {
+		ctx.SetStatusCode(http.StatusOK)
+	})
+
+	results, err := platform.CallFunctions(context.Background(), []FunctionCall{
+		{FunctionName: "echo", Event: &MemoryEvent{}},
+		{FunctionName: "missing", Event: &MemoryEvent{}},
+		{FunctionName: "echo", Event: &MemoryEvent{}},
+		{FunctionName: "other", Event: &MemoryEvent{}},
+	}, 0)
+
+	var callErrors CallErrors
+	if !errors.As(err, &callErrors) || len(callErrors) != 2 {
+		t.Fatalf("Expected two call errors, got %v", err)
+	}
+
+	if callErrors[0].Index != 1 || callErrors[1].Index != 3 || callErrors[1].FunctionName != "other" {
+		t.Fatalf("Bad call errors: %v", callErrors)
+	}
+
+	if results[0].Err != nil || results[1].Err == nil || results[2].Err != nil {
+		t.Fatalf("Bad results: %+v", results)
+	}
+
+	// errors.Is and errors.As look into the errors of the individual calls
+	if !errors.Is(fmt.Errorf("fan-out: %w", err), ErrFunctionNotResolved) {
+		t.Fatalf("Expected call errors to match the error of a call")
+	}
+
+	var callError *CallError
+	if !errors.As(err, &callError) || callError.Index != 1 {
+		t.Fatalf("Expected call errors to hold a call error, got %v", callError)
+	}
+}
+
+func TestCallFunctionsCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var numRequests int32
+
+	platform := newFanOutPlatform(t, func(requestCtx *fasthttp.RequestCtx) {
+
+		// the first call cancels the fan-out while in flight
+		if atomic.AddInt32(&numRequests, 1) == 1 {
+			cancel()
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+
+	var events []Event
+	for eventIndex := 0; eventIndex < 5; eventIndex++ {
+		events = append(events, &MemoryEvent{})
+	}
+
+	results, err := platform.CallFunctionWithEvents(ctx, "echo", events, 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected cancellation, got %v", err)
+	}
+
+	for resultIndex, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Fatalf("Expected call %d to be cancelled, got %v", resultIndex, result.Err)
+		}
+	}
+
+	if numRequests := atomic.LoadInt32(&numRequests); numRequests != 1 {
+		t.Fatalf("Expected calls after the cancellation not to be sent, got %d requests", numRequests)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestCallFunctionTimeout(t *testing.T) {
+	address := startFunctionServer(t, func(ctx *fasthttp.RequestCtx) {
+		time.Sleep(500 * time.Millisecond)
+	})
+
+	platform := newTestPlatform(t, &PlatformConfiguration{
+		FunctionResolver: StaticFunctionResolver{"slow": address},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := platform.CallFunctionWithContext(ctx, "slow", &MemoryEvent{})
+	if err == nil {
+		t.Fatalf("Expected call to time out")
+	}
+
+	if wsc, ok := err.(WithStatusCode); !ok || wsc.StatusCode() != http.StatusGatewayTimeout {
+		t.Fatalf("Expected gateway timeout, got: %s", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := platform.CallFunctionWithContext(ctx, "slow", &MemoryEvent{}); err != context.Canceled {
+		t.Fatalf("Expected cancellation, got: %v", err)
+	}
+}
+
+func TestCallFunctionCallTimeout(t *testing.T) {
+	if NewDefaultPlatformConfiguration().CallTimeout != DefaultCallTimeout {
+		t.Fatalf("Default configuration should bound calls")
+	}
+
+	address := startFunctionServer(t, func(ctx *fasthttp.RequestCtx) {
+		time.Sleep(500 * time.Millisecond)
+	})
+
+	platform := newTestPlatform(t, &PlatformConfiguration{
+		CallTimeout:      50 * time.Millisecond,
+		FunctionResolver: StaticFunctionResolver{"slow": address},
+	})
+
+	// the call timeout applies without a context deadline
+	startTime := time.Now()
+
+	_, err := platform.CallFunction("slow", &MemoryEvent{})
+	if !errors.Is(err, ErrGatewayTimeout) {
+		t.Fatalf("Expected gateway timeout, got: %v", err)
+	}
+
+	if elapsed := time.Since(startTime); elapsed > 400*time.Millisecond {
+		t.Fatalf("Call took %s despite the call timeout", elapsed)
+	}
+}
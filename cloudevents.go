@@ -0,0 +1,390 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentTypeCloudEventsJSON is the content type of CloudEvents in structured mode
+const ContentTypeCloudEventsJSON = "application/cloudevents+json"
+
+// CloudEventsSpecVersion is the version of the CloudEvents specification events are emitted in
+const CloudEventsSpecVersion = "1.0"
+
+// cloudEventsHeaderPrefix prefixes the headers of attributes in binary mode (e.g. ce-id)
+const cloudEventsHeaderPrefix = "Ce-"
+
+// ErrNotCloudEvent is returned when parsing an event which is not a CloudEvent
+var ErrNotCloudEvent = errors.New("Event is not a CloudEvent")
+
+// CloudEventMode is the way a CloudEvent is carried by an event or response
+type CloudEventMode int
+
+const (
+
+	// CloudEventModeBinary carries the attributes in ce-* headers and the data as the body
+	CloudEventModeBinary CloudEventMode = iota
+
+	// CloudEventModeStructured carries the attributes and the data in an application/cloudevents+json body
+	CloudEventModeStructured
+)
+
+// CloudEvent is an event in the CloudEvents format (https://cloudevents.io)
+type CloudEvent struct {
+	SpecVersion     string
+	ID              string
+	Source          string
+	Type            string
+	Subject         string
+	Time            time.Time
+	DataContentType string
+	DataSchema      string
+	Data            []byte
+
+	// Extensions holds the extension attributes, by their (lower case) names
+	Extensions map[string]interface{}
+
+	// Mode is the mode the event was parsed from
+	Mode CloudEventMode
+}
+
+// ParseCloudEvent parses a CloudEvent carried by the event in structured mode (a body of type
+// application/cloudevents+json) or in binary mode (ce-* headers). Events which are not CloudEvents
+// fail with ErrNotCloudEvent, and malformed CloudEvents with ErrBadRequest
+func ParseCloudEvent(event Event) (*CloudEvent, error) {
+	if getMediaType(event.GetContentType()) == ContentTypeCloudEventsJSON {
+		return parseStructuredCloudEvent(event.GetBody())
+	}
+
+	headers := Headers(event.GetHeaders())
+	if specVersion := headers.GetString(cloudEventsHeaderPrefix + "specversion"); specVersion != "" {
+		return parseBinaryCloudEvent(event, headers)
+	}
+
+	return nil, WrapErrBadRequest(ErrNotCloudEvent)
+}
+
+// NewCloudEventResponse creates a response carrying the CloudEvent in the given mode. The CloudEvent
+// must have an ID, a source and a type
+func NewCloudEventResponse(statusCode int, cloudEvent *CloudEvent, mode CloudEventMode) (Response, error) {
+	if cloudEvent.ID == "" || cloudEvent.Source == "" || cloudEvent.Type == "" {
+		return Response{}, errors.New("CloudEvent must have an ID, a source and a type")
+	}
+
+	if mode == CloudEventModeStructured {
+		body, err := json.Marshal(cloudEvent)
+		if err != nil {
+			return Response{}, fmt.Errorf("Failed to encode CloudEvent: %w", err)
+		}
+
+		return NewResponse(statusCode, ContentTypeCloudEventsJSON, body), nil
+	}
+
+	response := NewResponse(statusCode, cloudEvent.DataContentType, cloudEvent.Data)
+	if cloudEvent.DataContentType == "" {
+		response.Headers.Del("Content-Type")
+	}
+
+	for attributeName, value := range cloudEvent.getAttributes() {
+		if attributeName == "datacontenttype" {
+			continue
+		}
+
+		if timeValue, isTime := value.(time.Time); isTime {
+			value = timeValue.Format(time.RFC3339Nano)
+		}
+
+		response.Headers.Set(cloudEventsHeaderPrefix+attributeName, encodeCloudEventHeaderValue(fmt.Sprint(value)))
+	}
+
+	return response, nil
+}
+
+// SetJSONData sets the data of the CloudEvent to the value, encoded as JSON
+func (ce *CloudEvent) SetJSONData(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("Failed to encode CloudEvent data: %w", err)
+	}
+
+	ce.Data = data
+	ce.DataContentType = ContentTypeJSON
+
+	return nil
+}
+
+// DecodeData decodes the data of the CloudEvent, according to its content type, into the value
+func (ce *CloudEvent) DecodeData(value interface{}) error {
+	contentType := ce.DataContentType
+	if contentType == "" {
+		contentType = ContentTypeJSON
+	}
+
+	decoded, err := DecodeBody[interface{}](&MemoryEvent{ContentType: contentType, Body: ce.Data})
+	if err != nil {
+		return err
+	}
+
+	// decoding generically first supports all content types, then JSON maps the result to the value
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return WrapErrBadRequest(err)
+	}
+
+	return decodeJSON(encoded, value, false)
+}
+
+// MarshalJSON encodes the CloudEvent in structured mode. Data of JSON content types is embedded as
+// is, text data as a string and any other data in base64
+func (ce *CloudEvent) MarshalJSON() ([]byte, error) {
+	document := ce.getAttributes()
+
+	if timeValue, hasTime := document["time"].(time.Time); hasTime {
+		document["time"] = timeValue.Format(time.RFC3339Nano)
+	}
+
+	if len(ce.Data) > 0 {
+		mediaType := getMediaType(ce.DataContentType)
+
+		switch {
+		case (mediaType == "" || isJSONMediaType(mediaType)) && json.Valid(ce.Data):
+			document["data"] = json.RawMessage(ce.Data)
+		case strings.HasPrefix(mediaType, "text/") && utf8.Valid(ce.Data):
+			document["data"] = string(ce.Data)
+		default:
+			document["data_base64"] = base64.StdEncoding.EncodeToString(ce.Data)
+		}
+	}
+
+	return json.Marshal(document)
+}
+
+// getAttributes returns the attributes and extensions which are set, by their names
+func (ce *CloudEvent) getAttributes() map[string]interface{} {
+	attributes := make(map[string]interface{}, len(ce.Extensions)+8)
+
+	for extensionName, value := range ce.Extensions {
+		attributes[extensionName] = value
+	}
+
+	attributes["specversion"] = ce.SpecVersion
+	if ce.SpecVersion == "" {
+		attributes["specversion"] = CloudEventsSpecVersion
+	}
+
+	attributes["id"] = ce.ID
+	attributes["source"] = ce.Source
+	attributes["type"] = ce.Type
+
+	for attributeName, value := range map[string]string{
+		"subject":         ce.Subject,
+		"datacontenttype": ce.DataContentType,
+		"dataschema":      ce.DataSchema,
+	} {
+		if value != "" {
+			attributes[attributeName] = value
+		}
+	}
+
+	if !ce.Time.IsZero() {
+		attributes["time"] = ce.Time
+	}
+
+	return attributes
+}
+
+// setAttribute sets an attribute, or an extension if it is not one of the context attributes
+func (ce *CloudEvent) setAttribute(attributeName string, value interface{}) error {
+	stringValue, isString := value.(string)
+
+	switch attributeName {
+	case "specversion", "id", "source", "type", "subject", "datacontenttype", "dataschema", "time":
+		if !isString {
+			return fmt.Errorf("Attribute %s must be a string", attributeName)
+		}
+	default:
+		if ce.Extensions == nil {
+			ce.Extensions = map[string]interface{}{}
+		}
+
+		ce.Extensions[attributeName] = value
+		return nil
+	}
+
+	switch attributeName {
+	case "specversion":
+		ce.SpecVersion = stringValue
+	case "id":
+		ce.ID = stringValue
+	case "source":
+		ce.Source = stringValue
+	case "type":
+		ce.Type = stringValue
+	case "subject":
+		ce.Subject = stringValue
+	case "datacontenttype":
+		ce.DataContentType = stringValue
+	case "dataschema":
+		ce.DataSchema = stringValue
+	case "time":
+		eventTime, err := time.Parse(time.RFC3339Nano, stringValue)
+		if err != nil {
+			return fmt.Errorf("Invalid time %q: %w", stringValue, err)
+		}
+
+		ce.Time = eventTime
+	}
+
+	return nil
+}
+
+// validate checks that the required attributes are set
+func (ce *CloudEvent) validate() error {
+	if !strings.HasPrefix(ce.SpecVersion, "1.") {
+		return fmt.Errorf("Unsupported CloudEvents spec version %q", ce.SpecVersion)
+	}
+
+	for attributeName, value := range map[string]string{
+		"id":     ce.ID,
+		"source": ce.Source,
+		"type":   ce.Type,
+	} {
+		if value == "" {
+			return fmt.Errorf("Missing required attribute %s", attributeName)
+		}
+	}
+
+	return nil
+}
+
+func parseStructuredCloudEvent(body []byte) (*CloudEvent, error) {
+	document := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil, WrapErrBadRequest(fmt.Errorf("Failed to decode CloudEvent: %w", err))
+	}
+
+	cloudEvent := &CloudEvent{
+		Mode: CloudEventModeStructured,
+	}
+
+	for attributeName, rawValue := range document {
+		switch attributeName {
+		case "data":
+			cloudEvent.Data = rawValue
+		case "data_base64":
+			var encodedData string
+			if err := json.Unmarshal(rawValue, &encodedData); err != nil {
+				return nil, WrapErrBadRequest(fmt.Errorf("Invalid data_base64: %w", err))
+			}
+
+			data, err := base64.StdEncoding.DecodeString(encodedData)
+			if err != nil {
+				return nil, WrapErrBadRequest(fmt.Errorf("Invalid data_base64: %w", err))
+			}
+
+			cloudEvent.Data = data
+		default:
+			var value interface{}
+			if err := json.Unmarshal(rawValue, &value); err != nil {
+				return nil, WrapErrBadRequest(fmt.Errorf("Invalid attribute %s: %w", attributeName, err))
+			}
+
+			if err := cloudEvent.setAttribute(attributeName, value); err != nil {
+				return nil, WrapErrBadRequest(err)
+			}
+		}
+	}
+
+	// data which is a JSON string holds text, unless the data is itself JSON
+	if rawData, hasData := document["data"]; hasData {
+		mediaType := getMediaType(cloudEvent.DataContentType)
+		if mediaType != "" && !isJSONMediaType(mediaType) {
+			var text string
+			if err := json.Unmarshal(rawData, &text); err == nil {
+				cloudEvent.Data = []byte(text)
+			}
+		}
+	}
+
+	if err := cloudEvent.validate(); err != nil {
+		return nil, WrapErrBadRequest(err)
+	}
+
+	return cloudEvent, nil
+}
+
+func parseBinaryCloudEvent(event Event, headers Headers) (*CloudEvent, error) {
+	cloudEvent := &CloudEvent{
+		Mode:            CloudEventModeBinary,
+		DataContentType: headers.GetString("Content-Type"),
+		Data:            event.GetBody(),
+	}
+
+	if cloudEvent.DataContentType == "" {
+		cloudEvent.DataContentType = getEventContentType(event)
+	}
+
+	for headerKey := range headers {
+		if len(headerKey) <= len(cloudEventsHeaderPrefix) ||
+			!strings.EqualFold(headerKey[:len(cloudEventsHeaderPrefix)], cloudEventsHeaderPrefix) {
+			continue
+		}
+
+		attributeName := strings.ToLower(headerKey[len(cloudEventsHeaderPrefix):])
+
+		attributeValue, err := url.PathUnescape(headers.GetString(headerKey))
+		if err != nil {
+			return nil, WrapErrBadRequest(fmt.Errorf("Malformed value of header %s: %w", headerKey, err))
+		}
+
+		if err := cloudEvent.setAttribute(attributeName, attributeValue); err != nil {
+			return nil, WrapErrBadRequest(err)
+		}
+	}
+
+	if err := cloudEvent.validate(); err != nil {
+		return nil, WrapErrBadRequest(err)
+	}
+
+	return cloudEvent, nil
+}
+
+// encodeCloudEventHeaderValue percent-encodes the UTF-8 bytes of spaces, double quotes, percent signs
+// and characters outside of printable ASCII, as the HTTP binding of CloudEvents requires of attribute
+// header values
+func encodeCloudEventHeaderValue(value string) string {
+	var encodedValue strings.Builder
+
+	for byteIndex := 0; byteIndex < len(value); byteIndex++ {
+		valueByte := value[byteIndex]
+		if valueByte <= ' ' || valueByte > '~' || valueByte == '"' || valueByte == '%' {
+			fmt.Fprintf(&encodedValue, "%%%02X", valueByte)
+		} else {
+			encodedValue.WriteByte(valueByte)
+		}
+	}
+
+	return encodedValue.String()
+}
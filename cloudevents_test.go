@@ -0,0 +1,208 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseStructuredCloudEvent(t *testing.T) {
+	cloudEvent, err := ParseCloudEvent(&MemoryEvent{
+		ContentType: ContentTypeCloudEventsJSON + "; charset=utf-8",
+		Body: []byte(`{
+			"specversion": "1.0",
+			"id": "e1",
+			"source": "/orders",
+			"type": "order.created",
+			"subject": "o1",
+			"time": "2023-01-02T03:04:05Z",
+			"datacontenttype": "application/json",
+			"traceparent": "00-abc",
+			"data": {"quantity": 3}
+		}`),
+	})
+	if err != nil {
+		t.Fatalf("Failed to parse CloudEvent: %s", err)
+	}
+
+	if cloudEvent.Mode != CloudEventModeStructured ||
+		cloudEvent.ID != "e1" ||
+		cloudEvent.Source != "/orders" ||
+		cloudEvent.Subject != "o1" ||
+		!cloudEvent.Time.Equal(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)) ||
+		cloudEvent.Extensions["traceparent"] != "00-abc" {
+		t.Fatalf("Bad CloudEvent: %+v", cloudEvent)
+	}
+
+	data := struct {
+		Quantity int `json:"quantity"`
+	}{}
+
+	if err := cloudEvent.DecodeData(&data); err != nil || data.Quantity != 3 {
+		t.Fatalf("Bad data: %+v, %v", data, err)
+	}
+}
+
+func TestParseBinaryCloudEvent(t *testing.T) {
+	cloudEvent, err := ParseCloudEvent(&MemoryEvent{
+		ContentType: ContentTypeText,
+		Body:        []byte("hello"),
+		Headers: map[string]interface{}{
+			"ce-specversion":  "1.0",
+			"Ce-Id":           "e1",
+			"CE-SOURCE":       "/greetings",
+			"ce-type":         "greeting",
+			"ce-partitionkey": "p1",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to parse CloudEvent: %s", err)
+	}
+
+	if cloudEvent.Mode != CloudEventModeBinary ||
+		cloudEvent.Source != "/greetings" ||
+		cloudEvent.DataContentType != ContentTypeText ||
+		string(cloudEvent.Data) != "hello" ||
+		cloudEvent.Extensions["partitionkey"] != "p1" {
+		t.Fatalf("Bad CloudEvent: %+v", cloudEvent)
+	}
+}
+
+func TestParseCloudEventErrors(t *testing.T) {
+	if _, err := ParseCloudEvent(&MemoryEvent{ContentType: ContentTypeJSON, Body: []byte(`{}`)}); !errors.Is(err, ErrNotCloudEvent) {
+		t.Fatalf("Expected not a CloudEvent, got %v", err)
+	}
+
+	_, err := ParseCloudEvent(&MemoryEvent{
+		ContentType: ContentTypeCloudEventsJSON,
+		Body:        []byte(`{"specversion": "1.0", "id": "e1", "type": "t"}`),
+	})
+	if !errors.Is(err, ErrBadRequest) {
+		t.Fatalf("Expected bad request for missing source, got %v", err)
+	}
+}
+
+func TestNewCloudEventResponse(t *testing.T) {
+	cloudEvent := &CloudEvent{
+		ID:         "e2",
+		Source:     "/orders",
+		Type:       "order.shipped",
+		Time:       time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		Extensions: map[string]interface{}{"partitionkey": "p1"},
+	}
+
+	if err := cloudEvent.SetJSONData(map[string]int{"quantity": 3}); err != nil {
+		t.Fatalf("Failed to set data: %s", err)
+	}
+
+	response, err := NewCloudEventResponse(http.StatusOK, cloudEvent, CloudEventModeBinary)
+	if err != nil {
+		t.Fatalf("Failed to create binary response: %s", err)
+	}
+
+	for headerKey, expectedValue := range map[string]string{
+		"ce-specversion":  "1.0",
+		"ce-id":           "e2",
+		"ce-time":         "2023-01-02T03:04:05Z",
+		"ce-partitionkey": "p1",
+		"Content-Type":    ContentTypeJSON,
+	} {
+		if value := response.Headers.GetString(headerKey); value != expectedValue {
+			t.Fatalf("Bad header %s: %q", headerKey, value)
+		}
+	}
+
+	if string(response.Body) != `{"quantity":3}` {
+		t.Fatalf("Bad binary body: %s", response.Body)
+	}
+
+	response, err = NewCloudEventResponse(http.StatusOK, cloudEvent, CloudEventModeStructured)
+	if err != nil {
+		t.Fatalf("Failed to create structured response: %s", err)
+	}
+
+	document := map[string]interface{}{}
+	if err := json.Unmarshal(response.Body, &document); err != nil {
+		t.Fatalf("Failed to decode structured body: %s", err)
+	}
+
+	if response.ContentType != ContentTypeCloudEventsJSON ||
+		document["specversion"] != "1.0" ||
+		document["partitionkey"] != "p1" ||
+		document["data"].(map[string]interface{})["quantity"] != float64(3) {
+		t.Fatalf("Bad structured response: %s", response.Body)
+	}
+
+	// the structured response parses back to the same CloudEvent
+	parsedCloudEvent, err := ParseCloudEvent(&MemoryEvent{ContentType: response.ContentType, Body: response.Body})
+	if err != nil || parsedCloudEvent.ID != "e2" || string(parsedCloudEvent.Data) != `{"quantity":3}` {
+		t.Fatalf("Failed to parse structured response: %+v, %v", parsedCloudEvent, err)
+	}
+
+	// binary data is base64 encoded in structured mode
+	cloudEvent.DataContentType = ContentTypeOctetStream
+	cloudEvent.Data = []byte{0xff, 0x00}
+
+	response, _ = NewCloudEventResponse(http.StatusOK, cloudEvent, CloudEventModeStructured)
+	parsedCloudEvent, err = ParseCloudEvent(&MemoryEvent{ContentType: response.ContentType, Body: response.Body})
+	if err != nil || string(parsedCloudEvent.Data) != string([]byte{0xff, 0x00}) {
+		t.Fatalf("Failed to round trip binary data: %+v, %v", parsedCloudEvent, err)
+	}
+
+	if _, err := NewCloudEventResponse(http.StatusOK, &CloudEvent{ID: "e3"}, CloudEventModeBinary); err == nil {
+		t.Fatalf("Expected error for CloudEvent without source and type")
+	}
+}
+
+func TestCloudEventHeaderEncoding(t *testing.T) {
+	cloudEvent := &CloudEvent{
+		ID:      "e4",
+		Source:  "/orders",
+		Type:    "order.shipped",
+		Subject: `Grüße 100% "done"`,
+	}
+
+	response, err := NewCloudEventResponse(http.StatusOK, cloudEvent, CloudEventModeBinary)
+	if err != nil {
+		t.Fatalf("Failed to create binary response: %s", err)
+	}
+
+	if subject := response.Headers.GetString("ce-subject"); subject != "Gr%C3%BC%C3%9Fe%20100%25%20%22done%22" {
+		t.Fatalf("Bad encoded subject: %s", subject)
+	}
+
+	parsedCloudEvent, err := ParseCloudEvent(&MemoryEvent{Headers: response.Headers})
+	if err != nil || parsedCloudEvent.Subject != cloudEvent.Subject {
+		t.Fatalf("Failed to round trip subject: %+v, %v", parsedCloudEvent, err)
+	}
+
+	_, err = ParseCloudEvent(&MemoryEvent{
+		Headers: map[string]interface{}{
+			"ce-specversion": "1.0",
+			"ce-id":          "e5",
+			"ce-source":      "/orders%zz",
+			"ce-type":        "order.shipped",
+		},
+	})
+	if !errors.Is(err, ErrBadRequest) {
+		t.Fatalf("Expected bad request for malformed percent-encoding, got %v", err)
+	}
+}
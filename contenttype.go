@@ -0,0 +1,80 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"mime"
+	"strings"
+)
+
+// Content types understood by the body decoding and encoding helpers
+const (
+	ContentTypeJSON        = "application/json"
+	ContentTypeForm        = "application/x-www-form-urlencoded"
+	ContentTypeMsgpack     = "application/msgpack"
+	ContentTypeYAML        = "application/yaml"
+	ContentTypeText        = "text/plain"
+	ContentTypeOctetStream = "application/octet-stream"
+	ContentTypeProblemJSON = "application/problem+json"
+)
+
+// msgpack has no registered media type, and is sent under several names
+var msgpackContentTypes = map[string]bool{
+	ContentTypeMsgpack:          true,
+	"application/x-msgpack":     true,
+	"application/vnd.msgpack":   true,
+	"application/x-messagepack": true,
+}
+
+var yamlContentTypes = map[string]bool{
+	ContentTypeYAML:      true,
+	"application/x-yaml": true,
+	"text/yaml":          true,
+	"text/x-yaml":        true,
+}
+
+// isSameMediaType checks if the media types are the same, or names of the same format (e.g.
+// application/yaml and text/yaml)
+func isSameMediaType(mediaType string, otherMediaType string) bool {
+	if mediaType == otherMediaType {
+		return true
+	}
+
+	for _, aliasedContentTypes := range []map[string]bool{msgpackContentTypes, yamlContentTypes} {
+		if aliasedContentTypes[mediaType] && aliasedContentTypes[otherMediaType] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getMediaType returns the lower case media type of a content type, without its parameters
+func getMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	}
+
+	return strings.ToLower(mediaType)
+}
+
+// isJSONMediaType checks if the media type is JSON, including structured syntax suffixes
+// (e.g. application/problem+json)
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == ContentTypeJSON || strings.HasSuffix(mediaType, "+json")
+}
@@ -56,3 +56,21 @@ type Context struct {
 	// WorkerAllocatorName holds the name of the worker allocator
 	WorkerAllocatorName string
 }
+
+// PlatformForEvent returns the platform of the context, bound to the trace of the event being
+// handled. Functions called through it become children of the trace carried by the event headers
+// (or of a new trace, if the event carries none) even when called with fresh events, so handlers
+// should call other functions through it rather than through Platform, which doesn't know which
+// event is being handled
+func (c *Context) PlatformForEvent(event Event) *Platform {
+	if c.Platform == nil {
+		return nil
+	}
+
+	traceContext := ExtractTraceContext(event)
+	if traceContext == nil {
+		traceContext = NewTraceContext()
+	}
+
+	return c.Platform.WithTraceContext(traceContext)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// conversion of header and field values, which triggers hold as strings, byte slices or native types
+
+func convertToString(value interface{}) (string, bool) {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue, true
+	case []byte:
+		return string(typedValue), true
+	case []string:
+		if len(typedValue) > 0 {
+			return typedValue[0], true
+		}
+	}
+
+	return "", false
+}
+
+func convertToInt64(value interface{}) (int64, error) {
+	if stringValue, isString := convertToString(value); isString {
+		int64Value, err := strconv.ParseInt(strings.TrimSpace(stringValue), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q is not an integer", ErrTypeConversion, stringValue)
+		}
+
+		return int64Value, nil
+	}
+
+	reflectValue := reflect.ValueOf(value)
+
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflectValue.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if reflectValue.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("%w: %d overflows int64", ErrTypeConversion, reflectValue.Uint())
+		}
+
+		return int64(reflectValue.Uint()), nil
+	}
+
+	return 0, newTypeConversionError(value, "integer")
+}
+
+func convertToInt(value interface{}) (int, error) {
+	int64Value, err := convertToInt64(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if int64Value > math.MaxInt || int64Value < math.MinInt {
+		return 0, fmt.Errorf("%w: %d overflows int", ErrTypeConversion, int64Value)
+	}
+
+	return int(int64Value), nil
+}
+
+// convertToIntAccessorValue converts values for GetHeaderInt and GetFieldInt. Values which are missing
+// or of unsupported types fail with the bare ErrTypeConversion, as they always did, so that callers
+// comparing the error with == keep working
+func convertToIntAccessorValue(value interface{}) (int, error) {
+	intValue, err := convertToInt(value)
+	if err != nil {
+		if _, isString := convertToString(value); !isString {
+			return 0, ErrTypeConversion
+		}
+	}
+
+	return intValue, err
+}
+
+func convertToFloat(value interface{}) (float64, error) {
+	if stringValue, isString := convertToString(value); isString {
+		floatValue, err := strconv.ParseFloat(strings.TrimSpace(stringValue), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q is not a number", ErrTypeConversion, stringValue)
+		}
+
+		return floatValue, nil
+	}
+
+	if floatValue, isNumber := toFloat64(value); isNumber {
+		return floatValue, nil
+	}
+
+	return 0, newTypeConversionError(value, "number")
+}
+
+func convertToBool(value interface{}) (bool, error) {
+	if boolValue, isBool := value.(bool); isBool {
+		return boolValue, nil
+	}
+
+	if stringValue, isString := convertToString(value); isString {
+		boolValue, err := strconv.ParseBool(strings.TrimSpace(stringValue))
+		if err != nil {
+			return false, fmt.Errorf("%w: %q is not a boolean", ErrTypeConversion, stringValue)
+		}
+
+		return boolValue, nil
+	}
+
+	if int64Value, err := convertToInt64(value); err == nil {
+		return int64Value != 0, nil
+	}
+
+	return false, newTypeConversionError(value, "boolean")
+}
+
+// convertToDuration converts durations, strings like "1m30s", and integers (or strings holding
+// integers) which are taken as seconds
+func convertToDuration(value interface{}) (time.Duration, error) {
+	if durationValue, isDuration := value.(time.Duration); isDuration {
+		return durationValue, nil
+	}
+
+	if stringValue, isString := convertToString(value); isString {
+		stringValue = strings.TrimSpace(stringValue)
+
+		if durationValue, err := time.ParseDuration(stringValue); err == nil {
+			return durationValue, nil
+		}
+
+		seconds, err := strconv.ParseInt(stringValue, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q is not a duration", ErrTypeConversion, stringValue)
+		}
+
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	if seconds, err := convertToInt64(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	return 0, newTypeConversionError(value, "duration")
+}
+
+// convertToTime converts times, RFC 3339 or HTTP date strings, and integers (or strings holding
+// integers) which are taken as seconds since the epoch
+func convertToTime(value interface{}) (time.Time, error) {
+	if timeValue, isTime := value.(time.Time); isTime {
+		return timeValue, nil
+	}
+
+	if stringValue, isString := convertToString(value); isString {
+		stringValue = strings.TrimSpace(stringValue)
+
+		if timeValue, err := time.Parse(time.RFC3339Nano, stringValue); err == nil {
+			return timeValue, nil
+		}
+
+		if timeValue, err := http.ParseTime(stringValue); err == nil {
+			return timeValue, nil
+		}
+
+		seconds, err := strconv.ParseInt(stringValue, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %q is not a time", ErrTypeConversion, stringValue)
+		}
+
+		return time.Unix(seconds, 0), nil
+	}
+
+	if seconds, err := convertToInt64(value); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+
+	return time.Time{}, newTypeConversionError(value, "time")
+}
+
+// convertToStringValues converts the values of headers which may repeat
+func convertToStringValues(value interface{}) []string {
+	switch typedValue := value.(type) {
+	case nil:
+		return nil
+	case []string:
+		return typedValue
+	case [][]byte:
+		values := make([]string, 0, len(typedValue))
+		for _, byteSliceValue := range typedValue {
+			values = append(values, string(byteSliceValue))
+		}
+
+		return values
+	case []interface{}:
+		values := make([]string, 0, len(typedValue))
+		for _, interfaceValue := range typedValue {
+			if stringValue, isString := convertToString(interfaceValue); isString {
+				values = append(values, stringValue)
+			} else {
+				values = append(values, fmt.Sprint(interfaceValue))
+			}
+		}
+
+		return values
+	}
+
+	if stringValue, isString := convertToString(value); isString {
+		if stringValue == "" {
+			return nil
+		}
+
+		return []string{stringValue}
+	}
+
+	return []string{fmt.Sprint(value)}
+}
+
+func newTypeConversionError(value interface{}, typeName string) error {
+	if value == nil {
+		return fmt.Errorf("%w: value is missing", ErrTypeConversion)
+	}
+
+	return fmt.Errorf("%w: cannot convert %T to %s", ErrTypeConversion, value, typeName)
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// DefaultMaxDecodedBodySize is the maximum size of a body decoded by DecodeBody
+const DefaultMaxDecodedBodySize = 10 * 1024 * 1024
+
+// DecodeOptions controls how DecodeBodyWithOptions decodes event bodies
+type DecodeOptions struct {
+
+	// MaxBodySize is the maximum size of a decoded body, in bytes. Zero means DefaultMaxDecodedBodySize
+	// and a negative value means no limit
+	MaxBodySize int
+
+	// DefaultContentType is assumed for events that have no content type. Zero means JSON
+	DefaultContentType string
+
+	// DisallowUnknownFields fails decoding JSON objects with fields the target does not have
+	DisallowUnknownFields bool
+}
+
+// DecodeBody decodes the body of the event according to its content type - JSON, form encoded or
+// msgpack. Malformed bodies fail with ErrBadRequest, unsupported content types with
+// ErrUnsupportedMediaType and bodies that are too large with ErrRequestEntityTooLarge
+func DecodeBody[T any](event Event) (T, error) {
+	return DecodeBodyWithOptions[T](event, nil)
+}
+
+// DecodeBodyWithOptions decodes the body of the event like DecodeBody, with the given options
+func DecodeBodyWithOptions[T any](event Event, options *DecodeOptions) (T, error) {
+	var value T
+
+	if options == nil {
+		options = &DecodeOptions{}
+	}
+
+	body := event.GetBody()
+
+	maxBodySize := options.MaxBodySize
+	if maxBodySize == 0 {
+		maxBodySize = DefaultMaxDecodedBodySize
+	}
+
+	if maxBodySize > 0 && len(body) > maxBodySize {
+		return value, NewErrRequestEntityTooLarge(fmt.Sprintf("Body of %d bytes exceeds the maximum of %d bytes",
+			len(body),
+			maxBodySize))
+	}
+
+	contentType := getEventContentType(event)
+	if contentType == "" {
+		contentType = options.DefaultContentType
+	}
+
+	if contentType == "" {
+		contentType = ContentTypeJSON
+	}
+
+	var err error
+	mediaType := getMediaType(contentType)
+
+	switch {
+	case isJSONMediaType(mediaType):
+		err = decodeJSON(body, &value, options.DisallowUnknownFields)
+	case mediaType == ContentTypeForm:
+		err = decodeForm(body, &value)
+	case msgpackContentTypes[mediaType]:
+		err = decodeMsgpack(body, &value)
+	default:
+		return value, NewErrUnsupportedMediaType(fmt.Sprintf("Unsupported content type: %s", contentType))
+	}
+
+	if err != nil {
+		return value, WrapErrBadRequest(fmt.Errorf("Failed to decode body as %s: %w", mediaType, err))
+	}
+
+	return value, nil
+}
+
+// contentTypeSpecifier is implemented by events which report a default content type when they have
+// none (like memory events, which report text/plain), to tell which content type was specified
+type contentTypeSpecifier interface {
+	GetSpecifiedContentType() string
+}
+
+// getEventContentType returns the content type of the event, or an empty string if it has none.
+// Wrapped events (e.g. routed events) are unwrapped to check whether their content type was specified
+func getEventContentType(event Event) string {
+	if specifier, ok := UnwrapEvent(event).(contentTypeSpecifier); ok {
+		return specifier.GetSpecifiedContentType()
+	}
+
+	return event.GetContentType()
+}
+
+func decodeJSON(body []byte, value interface{}, disallowUnknownFields bool) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	if err := decoder.Decode(value); err != nil {
+		return err
+	}
+
+	if decoder.More() {
+		return fmt.Errorf("Unexpected data after JSON value")
+	}
+
+	return nil
+}
+
+func decodeMsgpack(body []byte, value interface{}) error {
+	decoder := msgpack.NewDecoder(bytes.NewReader(body))
+
+	// allow decoding into structs which are only tagged for JSON
+	decoder.SetCustomStructTag("json")
+
+	return decoder.Decode(value)
+}
+
+// decodeForm decodes a form into maps of strings or string slices, or into a struct whose fields
+// are matched by their "form" tag, their "json" tag or their name (case insensitive)
+func decodeForm(body []byte, value interface{}) error {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return err
+	}
+
+	target := reflect.ValueOf(value).Elem()
+
+	switch typedValue := value.(type) {
+	case *url.Values:
+		*typedValue = values
+		return nil
+	case *map[string][]string:
+		*typedValue = values
+		return nil
+	case *map[string]string:
+		*typedValue = make(map[string]string, len(values))
+		for key := range values {
+			(*typedValue)[key] = values.Get(key)
+		}
+		return nil
+	case *map[string]interface{}:
+		*typedValue = make(map[string]interface{}, len(values))
+		for key := range values {
+			(*typedValue)[key] = values.Get(key)
+		}
+		return nil
+	case *interface{}:
+		formMap := make(map[string]interface{}, len(values))
+		for key := range values {
+			formMap[key] = values.Get(key)
+		}
+		*typedValue = formMap
+		return nil
+	}
+
+	if target.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+
+		target = target.Elem()
+	}
+
+	if target.Kind() != reflect.Struct {
+		return fmt.Errorf("Cannot decode form into %s", target.Type())
+	}
+
+	return decodeFormIntoStruct(values, target)
+}
+
+func decodeFormIntoStruct(values url.Values, target reflect.Value) error {
+	targetType := target.Type()
+
+	for fieldIndex := 0; fieldIndex < targetType.NumField(); fieldIndex++ {
+		field := targetType.Field(fieldIndex)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		fieldName := getFormFieldName(field)
+		if fieldName == "-" {
+			continue
+		}
+
+		fieldValues, found := lookupFormValues(values, fieldName)
+		if !found {
+			continue
+		}
+
+		if err := setFormFieldValue(target.Field(fieldIndex), fieldValues); err != nil {
+			return fmt.Errorf("Invalid value for field %s: %w", fieldName, err)
+		}
+	}
+
+	return nil
+}
+
+func getFormFieldName(field reflect.StructField) string {
+	for _, tagName := range []string{"form", "json"} {
+		if tag := strings.Split(field.Tag.Get(tagName), ",")[0]; tag != "" {
+			return tag
+		}
+	}
+
+	return field.Name
+}
+
+func lookupFormValues(values url.Values, fieldName string) ([]string, bool) {
+	if fieldValues, found := values[fieldName]; found {
+		return fieldValues, true
+	}
+
+	for key, fieldValues := range values {
+		if strings.EqualFold(key, fieldName) {
+			return fieldValues, true
+		}
+	}
+
+	return nil, false
+}
+
+func setFormFieldValue(field reflect.Value, fieldValues []string) error {
+	if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(field.Type(), len(fieldValues), len(fieldValues))
+		for valueIndex, fieldValue := range fieldValues {
+			if err := setFormScalarValue(slice.Index(valueIndex), fieldValue); err != nil {
+				return err
+			}
+		}
+
+		field.Set(slice)
+		return nil
+	}
+
+	return setFormScalarValue(field, fieldValues[0])
+}
+
+func setFormScalarValue(field reflect.Value, fieldValue string) error {
+	if field.Kind() == reflect.Ptr {
+		pointer := reflect.New(field.Type().Elem())
+		if err := setFormScalarValue(pointer.Elem(), fieldValue); err != nil {
+			return err
+		}
+
+		field.Set(pointer)
+		return nil
+	}
+
+	// durations are int64s, but are given as strings like "1m30s"
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		duration, err := time.ParseDuration(fieldValue)
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(int64(duration))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(fieldValue)
+	case reflect.Bool:
+		boolValue, err := strconv.ParseBool(fieldValue)
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(boolValue)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		intValue, err := strconv.ParseInt(fieldValue, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(intValue)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		uintValue, err := strconv.ParseUint(fieldValue, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(uintValue)
+	case reflect.Float32, reflect.Float64:
+		floatValue, err := strconv.ParseFloat(fieldValue, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(floatValue)
+	case reflect.Slice:
+		field.SetBytes([]byte(fieldValue))
+	default:
+		return fmt.Errorf("Unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type decodedOrder struct {
+	ID       string        `json:"id"`
+	Quantity int           `json:"quantity"`
+	Tags     []string      `json:"tags" form:"tag"`
+	Timeout  time.Duration `json:"timeout"`
+}
+
+func TestDecodeBody(t *testing.T) {
+	msgpackBody, err := msgpack.Marshal(map[string]interface{}{"id": "o1", "quantity": 3})
+	if err != nil {
+		t.Fatalf("Failed to encode msgpack: %s", err)
+	}
+
+	for _, testCase := range []struct {
+		name          string
+		event         *MemoryEvent
+		expectedOrder decodedOrder
+	}{
+		{
+			name: "json",
+			event: &MemoryEvent{
+				ContentType: "application/json; charset=utf-8",
+				Body:        []byte(`{"id": "o1", "quantity": 3, "tags": ["a", "b"]}`),
+			},
+			expectedOrder: decodedOrder{ID: "o1", Quantity: 3, Tags: []string{"a", "b"}},
+		},
+		{
+			name: "form",
+			event: &MemoryEvent{
+				ContentType: ContentTypeForm,
+				Body:        []byte("id=o1&quantity=3&tag=a&tag=b&timeout=1m"),
+			},
+			expectedOrder: decodedOrder{ID: "o1", Quantity: 3, Tags: []string{"a", "b"}, Timeout: time.Minute},
+		},
+		{
+			name: "msgpack",
+			event: &MemoryEvent{
+				ContentType: "application/x-msgpack",
+				Body:        msgpackBody,
+			},
+			expectedOrder: decodedOrder{ID: "o1", Quantity: 3},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			order, err := DecodeBody[decodedOrder](testCase.event)
+			if err != nil {
+				t.Fatalf("Failed to decode: %s", err)
+			}
+
+			if order.ID != testCase.expectedOrder.ID ||
+				order.Quantity != testCase.expectedOrder.Quantity ||
+				len(order.Tags) != len(testCase.expectedOrder.Tags) ||
+				order.Timeout != testCase.expectedOrder.Timeout {
+				t.Fatalf("Bad order: %+v != %+v", order, testCase.expectedOrder)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyErrors(t *testing.T) {
+	for _, testCase := range []struct {
+		name               string
+		event              *MemoryEvent
+		options            *DecodeOptions
+		expectedStatusCode int
+	}{
+		{
+			name:               "malformed",
+			event:              &MemoryEvent{ContentType: ContentTypeJSON, Body: []byte(`{"id":`)},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "unknownField",
+			event:              &MemoryEvent{ContentType: ContentTypeJSON, Body: []byte(`{"color": "red"}`)},
+			options:            &DecodeOptions{DisallowUnknownFields: true},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "unsupported",
+			event:              &MemoryEvent{ContentType: "text/csv", Body: []byte("a,b")},
+			expectedStatusCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:               "tooLarge",
+			event:              &MemoryEvent{ContentType: ContentTypeJSON, Body: []byte(`{"id": "o1"}`)},
+			options:            &DecodeOptions{MaxBodySize: 4},
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := DecodeBodyWithOptions[decodedOrder](testCase.event, testCase.options)
+			if err == nil {
+				t.Fatalf("Expected decoding to fail")
+			}
+
+			if statusCode := err.(WithStatusCode).StatusCode(); statusCode != testCase.expectedStatusCode {
+				t.Fatalf("Bad status code: %d != %d", statusCode, testCase.expectedStatusCode)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyWithoutContentType(t *testing.T) {
+	order, err := DecodeBody[decodedOrder](&MemoryEvent{Body: []byte(`{"id": "o1", "quantity": 3}`)})
+	if err != nil {
+		t.Fatalf("Failed to decode as JSON: %s", err)
+	}
+
+	if order.ID != "o1" || order.Quantity != 3 {
+		t.Fatalf("Bad order: %+v", order)
+	}
+
+	order, err = DecodeBodyWithOptions[decodedOrder](NewMemoryEvent().WithBody("", []byte("id=o2&quantity=4")),
+		&DecodeOptions{DefaultContentType: ContentTypeForm})
+	if err != nil {
+		t.Fatalf("Failed to decode as default content type: %s", err)
+	}
+
+	if order.ID != "o2" || order.Quantity != 4 {
+		t.Fatalf("Bad order: %+v", order)
+	}
+
+	// an explicit content type is never overridden by the default
+	_, err = DecodeBody[decodedOrder](&MemoryEvent{ContentType: "text/plain", Body: []byte(`{"id": "o3"}`)})
+	if err == nil || err.(WithStatusCode).StatusCode() != http.StatusUnsupportedMediaType {
+		t.Fatalf("Expected explicit text/plain to be unsupported, got %v", err)
+	}
+}
+
+func TestDecodeBodyWithoutContentTypeOfWrappedEvent(t *testing.T) {
+	decodeOrder := func(context *Context, event Event) (interface{}, error) {
+		return DecodeBody[decodedOrder](event)
+	}
+
+	event := &MemoryEvent{Method: "POST", Path: "/orders", Body: []byte(`{"id": "o1", "quantity": 3}`)}
+
+	// routed events wrap the memory event, which still has no content type
+	result, err := NewRouter().Post("/orders", decodeOrder).Route(nil, event)
+	if err != nil {
+		t.Fatalf("Failed to decode routed event: %s", err)
+	}
+
+	if order := result.(decodedOrder); order.ID != "o1" || order.Quantity != 3 {
+		t.Fatalf("Bad order: %+v", order)
+	}
+
+	// replayed events have no content type either
+	data, err := MarshalEvent(event)
+	if err != nil {
+		t.Fatalf("Failed to marshal event: %s", err)
+	}
+
+	replayedEvent, err := UnmarshalEvent(data)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal event: %s", err)
+	}
+
+	if replayedEvent.ContentType != "" {
+		t.Fatalf("Unexpected recorded content type: %s", replayedEvent.ContentType)
+	}
+
+	if _, err := decodeOrder(nil, replayedEvent); err != nil {
+		t.Fatalf("Failed to decode replayed event: %s", err)
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// NegotiableContentTypes are the content types NewNegotiatedResponse can encode to, in order of preference
+var NegotiableContentTypes = []string{
+	ContentTypeJSON,
+	ContentTypeYAML,
+	ContentTypeMsgpack,
+	ContentTypeText,
+}
+
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+// NewResponse creates a response with the given body, setting the Content-Type and Content-Length headers
+func NewResponse(statusCode int, contentType string, body []byte) Response {
+	return Response{
+		StatusCode:  statusCode,
+		ContentType: contentType,
+		Headers: Headers{
+			"Content-Type":   contentType,
+			"Content-Length": strconv.Itoa(len(body)),
+		},
+		Body: body,
+	}
+}
+
+// NewTextResponse creates a text/plain response
+func NewTextResponse(statusCode int, text string) Response {
+	return NewResponse(statusCode, ContentTypeText+"; charset=utf-8", []byte(text))
+}
+
+// NewJSONResponse creates a response holding the value encoded as JSON
+func NewJSONResponse(statusCode int, value interface{}) (Response, error) {
+	return newEncodedResponse(statusCode, ContentTypeJSON, value)
+}
+
+// NewYAMLResponse creates a response holding the value encoded as YAML
+func NewYAMLResponse(statusCode int, value interface{}) (Response, error) {
+	return newEncodedResponse(statusCode, ContentTypeYAML, value)
+}
+
+// NewMsgpackResponse creates a response holding the value encoded as msgpack
+func NewMsgpackResponse(statusCode int, value interface{}) (Response, error) {
+	return newEncodedResponse(statusCode, ContentTypeMsgpack, value)
+}
+
+// NewNegotiatedResponse creates a response holding the value, encoded in the format that best matches
+// the Accept header of the event (JSON if the event has none). Fails with ErrNotAcceptable if none of
+// NegotiableContentTypes is acceptable
+func NewNegotiatedResponse(event Event, statusCode int, value interface{}) (Response, error) {
+	accept := getEventHeaderString(event, "Accept")
+	if accept == "" {
+		return NewJSONResponse(statusCode, value)
+	}
+
+	contentType := NegotiateContentType(accept, NegotiableContentTypes)
+	if contentType == "" {
+		return Response{}, NewErrNotAcceptable(fmt.Sprintf("Cannot respond with any of: %s", accept))
+	}
+
+	return newEncodedResponse(statusCode, contentType, value)
+}
+
+// NegotiateContentType returns the offered content type that best matches the Accept header, or an
+// empty string if none is acceptable. Ties are broken by the order of the offered content types.
+// Accepted names of a format (e.g. text/yaml) match the offered name of the same format
+func NegotiateContentType(accept string, offered []string) string {
+	acceptRanges := parseAccept(accept)
+
+	bestContentType, bestQuality := "", 0.0
+	for _, contentType := range offered {
+		quality := getAcceptQuality(acceptRanges, getMediaType(contentType))
+		if quality > bestQuality {
+			bestContentType, bestQuality = contentType, quality
+		}
+	}
+
+	return bestContentType
+}
+
+// EncodeBody encodes the value in the given content type
+func EncodeBody(contentType string, value interface{}) ([]byte, error) {
+	mediaType := getMediaType(contentType)
+
+	switch {
+	case isJSONMediaType(mediaType):
+		return json.Marshal(value)
+	case yamlContentTypes[mediaType]:
+		return yaml.Marshal(value)
+	case msgpackContentTypes[mediaType]:
+		buffer := bytes.Buffer{}
+
+		encoder := msgpack.NewEncoder(&buffer)
+		encoder.SetCustomStructTag("json")
+
+		if err := encoder.Encode(value); err != nil {
+			return nil, err
+		}
+
+		return buffer.Bytes(), nil
+	case mediaType == ContentTypeText:
+		return encodeText(value), nil
+	default:
+		return nil, fmt.Errorf("Cannot encode to content type %s", contentType)
+	}
+}
+
+func newEncodedResponse(statusCode int, contentType string, value interface{}) (Response, error) {
+	body, err := EncodeBody(contentType, value)
+	if err != nil {
+		return Response{}, WrapErrInternalServerError(fmt.Errorf("Failed to encode response: %w", err))
+	}
+
+	if contentType == ContentTypeText {
+		contentType += "; charset=utf-8"
+	}
+
+	return NewResponse(statusCode, contentType, body), nil
+}
+
+func encodeText(value interface{}) []byte {
+	switch typedValue := value.(type) {
+	case string:
+		return []byte(typedValue)
+	case []byte:
+		return typedValue
+	case error:
+		return []byte(typedValue.Error())
+	case fmt.Stringer:
+		return []byte(typedValue.String())
+	default:
+		return []byte(fmt.Sprint(value))
+	}
+}
+
+// parseAccept parses an Accept header into its ranges, ordered from the most to the least specific
+func parseAccept(accept string) []acceptRange {
+	var acceptRanges []acceptRange
+
+	for _, part := range strings.Split(accept, ",") {
+		parameters := strings.Split(part, ";")
+
+		mediaType := strings.ToLower(strings.TrimSpace(parameters[0]))
+		if mediaType == "" {
+			continue
+		}
+
+		if mediaType == "*" {
+			mediaType = "*/*"
+		}
+
+		quality := 1.0
+		for _, parameter := range parameters[1:] {
+			key, value, found := strings.Cut(strings.TrimSpace(parameter), "=")
+			if !found || strings.TrimSpace(key) != "q" {
+				continue
+			}
+
+			if parsedQuality, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				quality = parsedQuality
+			}
+		}
+
+		acceptRanges = append(acceptRanges, acceptRange{mediaType: mediaType, quality: quality})
+	}
+
+	sort.SliceStable(acceptRanges, func(i, j int) bool {
+		return getAcceptRangeSpecificity(acceptRanges[i].mediaType) > getAcceptRangeSpecificity(acceptRanges[j].mediaType)
+	})
+
+	return acceptRanges
+}
+
+// getAcceptQuality returns the quality of the most specific range matching the media type
+func getAcceptQuality(acceptRanges []acceptRange, mediaType string) float64 {
+	mainType := strings.SplitN(mediaType, "/", 2)[0]
+
+	for _, acceptRange := range acceptRanges {
+		if isSameMediaType(acceptRange.mediaType, mediaType) ||
+			acceptRange.mediaType == mainType+"/*" ||
+			acceptRange.mediaType == "*/*" {
+			return acceptRange.quality
+		}
+	}
+
+	return 0
+}
+
+func getAcceptRangeSpecificity(mediaType string) int {
+	switch {
+	case mediaType == "*/*":
+		return 0
+	case strings.HasSuffix(mediaType, "/*"):
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	for _, testCase := range []struct {
+		accept              string
+		expectedContentType string
+	}{
+		{accept: "application/json", expectedContentType: ContentTypeJSON},
+		{accept: "application/yaml, application/json;q=0.5", expectedContentType: ContentTypeYAML},
+		{accept: "text/*", expectedContentType: ContentTypeText},
+		{accept: "*/*", expectedContentType: ContentTypeJSON},
+		{accept: "application/json;q=0, */*;q=0.1", expectedContentType: ContentTypeYAML},
+		{accept: "image/png", expectedContentType: ""},
+		{accept: "application/x-yaml", expectedContentType: ContentTypeYAML},
+		{accept: "text/yaml, application/json;q=0.5", expectedContentType: ContentTypeYAML},
+		{accept: "application/x-msgpack", expectedContentType: ContentTypeMsgpack},
+	} {
+		contentType := NegotiateContentType(testCase.accept, NegotiableContentTypes)
+		if contentType != testCase.expectedContentType {
+			t.Fatalf("Bad content type for %q: %q != %q", testCase.accept, contentType, testCase.expectedContentType)
+		}
+	}
+}
+
+func TestNewNegotiatedResponse(t *testing.T) {
+	value := map[string]interface{}{"name": "nuclio"}
+
+	response, err := NewNegotiatedResponse(&MemoryEvent{
+		Headers: map[string]interface{}{"Accept": "application/yaml"},
+	}, http.StatusOK, value)
+	if err != nil {
+		t.Fatalf("Failed to create response: %s", err)
+	}
+
+	if response.ContentType != ContentTypeYAML || string(response.Body) != "name: nuclio\n" {
+		t.Fatalf("Bad response: %s %q", response.ContentType, response.Body)
+	}
+
+	if response.Headers["Content-Length"] != "13" {
+		t.Fatalf("Bad content length: %v", response.Headers["Content-Length"])
+	}
+
+	response, err = NewNegotiatedResponse(&MemoryEvent{}, http.StatusCreated, value)
+	if err != nil {
+		t.Fatalf("Failed to create response: %s", err)
+	}
+
+	if response.StatusCode != http.StatusCreated || string(response.Body) != `{"name":"nuclio"}` {
+		t.Fatalf("Bad default response: %d %s", response.StatusCode, response.Body)
+	}
+
+	// aliases of a format are responded to with its canonical content type
+	response, err = NewNegotiatedResponse(&MemoryEvent{
+		Headers: map[string]interface{}{"Accept": "application/x-msgpack"},
+	}, http.StatusOK, value)
+	if err != nil || response.ContentType != ContentTypeMsgpack {
+		t.Fatalf("Bad msgpack response: %s, %v", response.ContentType, err)
+	}
+
+	_, err = NewNegotiatedResponse(&MemoryEvent{
+		Headers: map[string]interface{}{"Accept": "image/png"},
+	}, http.StatusOK, value)
+	if err == nil || err.(WithStatusCode).StatusCode() != http.StatusNotAcceptable {
+		t.Fatalf("Expected not acceptable, got: %v", err)
+	}
+}
@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-//go:generate go run gen_errors.go
+//go:generate go run gen_errors.go -extra extra_status_codes.json
 //go:generate go fmt errors.go
 
 package nuclio
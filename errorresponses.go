@@ -0,0 +1,63 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// NewErrTooManyRequestsRetryAfter returns a new ErrTooManyRequests which tells the client to retry
+// after the given duration, through the Retry-After header
+func NewErrTooManyRequestsRetryAfter(retryAfter time.Duration) error {
+	return ErrTooManyRequests.WithHeader("Retry-After", formatRetryAfter(retryAfter))
+}
+
+// NewErrServiceUnavailableRetryAfter returns a new ErrServiceUnavailable which tells the client to
+// retry after the given duration, through the Retry-After header
+func NewErrServiceUnavailableRetryAfter(retryAfter time.Duration) error {
+	return ErrServiceUnavailable.WithHeader("Retry-After", formatRetryAfter(retryAfter))
+}
+
+// NewErrUnauthorizedWithChallenge returns a new ErrUnauthorized which tells the client how to
+// authenticate, through the WWW-Authenticate header (e.g. `Bearer realm="orders"`)
+func NewErrUnauthorizedWithChallenge(challenge string) error {
+	return ErrUnauthorized.WithHeader("WWW-Authenticate", challenge)
+}
+
+// NewRedirect returns an error which redirects the client to the location with the given 3xx status
+// code (e.g. http.StatusFound). Status codes other than 3xx are replaced with http.StatusFound
+func NewRedirect(statusCode int, location string) error {
+	if statusCode < http.StatusMultipleChoices || statusCode >= http.StatusBadRequest {
+		statusCode = http.StatusFound
+	}
+
+	redirect := ErrorWithStatusCode{statusCode: statusCode}
+
+	return redirect.WithHeader("Location", location)
+}
+
+// formatRetryAfter formats a duration as a Retry-After header, in whole seconds rounded up
+func formatRetryAfter(retryAfter time.Duration) string {
+	if retryAfter < 0 {
+		retryAfter = 0
+	}
+
+	return strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+}
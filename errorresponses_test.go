@@ -0,0 +1,94 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestErrorResponseHeaders(t *testing.T) {
+	for _, testCase := range []struct {
+		name               string
+		err                error
+		expectedStatusCode int
+		expectedHeader     string
+		expectedValue      string
+	}{
+		{
+			name:               "retryAfter",
+			err:                NewErrTooManyRequestsRetryAfter(1500 * time.Millisecond),
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedHeader:     "Retry-After",
+			expectedValue:      "2",
+		},
+		{
+			name:               "challenge",
+			err:                NewErrUnauthorizedWithChallenge(`Bearer realm="orders"`),
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedHeader:     "WWW-Authenticate",
+			expectedValue:      `Bearer realm="orders"`,
+		},
+		{
+			name:               "redirect",
+			err:                NewRedirect(http.StatusMovedPermanently, "https://nuclio.io"),
+			expectedStatusCode: http.StatusMovedPermanently,
+			expectedHeader:     "Location",
+			expectedValue:      "https://nuclio.io",
+		},
+		{
+			name:               "invalidRedirect",
+			err:                NewRedirect(http.StatusOK, "/elsewhere"),
+			expectedStatusCode: http.StatusFound,
+			expectedHeader:     "Location",
+			expectedValue:      "/elsewhere",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if statusCode := StatusCodeOf(testCase.err); statusCode != testCase.expectedStatusCode {
+				t.Fatalf("Bad status code: %d != %d", statusCode, testCase.expectedStatusCode)
+			}
+
+			var withResponse WithResponse
+			if !errors.As(testCase.err, &withResponse) {
+				t.Fatalf("Error does not specify a response")
+			}
+
+			if value := withResponse.ResponseHeaders()[testCase.expectedHeader]; value != testCase.expectedValue {
+				t.Fatalf("Bad %s: %v != %s", testCase.expectedHeader, value, testCase.expectedValue)
+			}
+		})
+	}
+
+	if ErrTooManyRequests.ResponseHeaders() != nil {
+		t.Fatalf("Sentinel should not be modified")
+	}
+}
+
+func TestErrorResponseBody(t *testing.T) {
+	err := ErrConflict.WithBody(ContentTypeJSON, []byte(`{"reason":"exists"}`))
+
+	if err.ResponseContentType() != ContentTypeJSON || string(err.ResponseBody()) != `{"reason":"exists"}` {
+		t.Fatalf("Bad body: %s %s", err.ResponseContentType(), err.ResponseBody())
+	}
+
+	if err.Error() != "Conflict" {
+		t.Fatalf("Bad message: %s", err.Error())
+	}
+}
@@ -29,22 +29,131 @@ type WithStatusCode interface {
 	StatusCode() int
 }
 
+// WithAttributes is an error carrying structured key / value pairs, in the form accepted by the
+// structured logger functions (e.g. InfoWith)
+type WithAttributes interface {
+	Attributes() []interface{}
+}
+
+// WithResponse is an error specifying parts of the response sent when a handler returns it
+type WithResponse interface {
+	ResponseHeaders() map[string]interface{}
+	ResponseContentType() string
+	ResponseBody() []byte
+}
+
 // ErrorWithStatusCode implements both error and WithStatusCode
 type ErrorWithStatusCode struct {
 	error
 	statusCode int
+
+	// details is a pointer, so that errors remain comparable
+	details *errorDetails
+}
+
+// errorDetails holds the optional parts of an ErrorWithStatusCode
+type errorDetails struct {
+	attributes  []interface{}
+	headers     map[string]interface{}
+	contentType string
+	body        []byte
+}
+
+func (ed *errorDetails) clone() *errorDetails {
+	if ed == nil {
+		return &errorDetails{headers: map[string]interface{}{}}
+	}
+
+	clonedDetails := *ed
+	clonedDetails.headers = make(map[string]interface{}, len(ed.headers))
+	for headerKey, headerValue := range ed.headers {
+		clonedDetails.headers[headerKey] = headerValue
+	}
+
+	return &clonedDetails
 }
 
 // GetError returns the underlying error
-func (e *ErrorWithStatusCode) GetError() error {
+func (e ErrorWithStatusCode) GetError() error {
 	return e.error
 }
 
 // StatusCode returns the status code
-func (e *ErrorWithStatusCode) StatusCode() int {
+func (e ErrorWithStatusCode) StatusCode() int {
 	return e.statusCode
 }
 
+// Attributes returns the key / value pairs attached to the error
+func (e ErrorWithStatusCode) Attributes() []interface{} {
+	if e.details == nil {
+		return nil
+	}
+
+	return e.details.attributes
+}
+
+// ResponseHeaders returns the headers to set on the response
+func (e ErrorWithStatusCode) ResponseHeaders() map[string]interface{} {
+	if e.details == nil {
+		return nil
+	}
+
+	return e.details.headers
+}
+
+// ResponseContentType returns the content type of the response body, if set
+func (e ErrorWithStatusCode) ResponseContentType() string {
+	if e.details == nil {
+		return ""
+	}
+
+	return e.details.contentType
+}
+
+// ResponseBody returns the response body, if set. Otherwise, the error message is sent
+func (e ErrorWithStatusCode) ResponseBody() []byte {
+	if e.details == nil {
+		return nil
+	}
+
+	return e.details.body
+}
+
+// WithHeader returns a copy of the error which sets the header on the response
+func (e ErrorWithStatusCode) WithHeader(key string, value interface{}) *ErrorWithStatusCode {
+	e.details = e.details.clone()
+	e.details.headers[key] = value
+
+	return &e
+}
+
+// WithBody returns a copy of the error which responds with the given body rather than the error message
+func (e ErrorWithStatusCode) WithBody(contentType string, body []byte) *ErrorWithStatusCode {
+	e.details = e.details.clone()
+	e.details.contentType = contentType
+	e.details.body = body
+
+	return &e
+}
+
+// Unwrap returns the underlying error, allowing errors.Is and errors.As to inspect it
+func (e ErrorWithStatusCode) Unwrap() error {
+	return e.error
+}
+
+// Is matches errors with status code by their status code, so that errors.Is(NewErrNotFound("..."),
+// ErrNotFound) holds
+func (e ErrorWithStatusCode) Is(target error) bool {
+	switch typedTarget := target.(type) {
+	case ErrorWithStatusCode:
+		return typedTarget.statusCode == e.statusCode
+	case *ErrorWithStatusCode:
+		return typedTarget != nil && typedTarget.statusCode == e.statusCode
+	}
+
+	return false
+}
+
 // Error returns the error message
 func (e ErrorWithStatusCode) Error() string {
 	if e.error != nil {
@@ -59,6 +168,13 @@ func (e ErrorWithStatusCode) Error() string {
 	return message
 }
 
+// Status codes which are not defined by net/http
+const (
+	StatusClientClosedRequest   = 499
+	StatusNetworkReadTimeout    = 598
+	StatusNetworkConnectTimeout = 599
+)
+
 // ErrAccepted is a StatusAccepted Error
 var ErrAccepted = ErrorWithStatusCode{statusCode: http.StatusAccepted}
 
@@ -70,6 +186,23 @@ func NewErrAccepted(message string) error {
 	}
 }
 
+// NewErrAcceptedf returns a new ErrAccepted with a formatted error message
+func NewErrAcceptedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusAccepted,
+	}
+}
+
+// NewErrAcceptedWith returns a new ErrAccepted with custom error message and key / value attributes
+func NewErrAcceptedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusAccepted,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrAccepted returns a new ErrAccepted, wrapping an existing error
 func WrapErrAccepted(err error) error {
 	return &ErrorWithStatusCode{
@@ -78,6 +211,14 @@ func WrapErrAccepted(err error) error {
 	}
 }
 
+// WrapErrAcceptedf returns a new ErrAccepted, wrapping an existing error with a formatted message
+func WrapErrAcceptedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusAccepted,
+	}
+}
+
 // ErrAlreadyReported is a StatusAlreadyReported Error
 var ErrAlreadyReported = ErrorWithStatusCode{statusCode: http.StatusAlreadyReported}
 
@@ -89,6 +230,23 @@ func NewErrAlreadyReported(message string) error {
 	}
 }
 
+// NewErrAlreadyReportedf returns a new ErrAlreadyReported with a formatted error message
+func NewErrAlreadyReportedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusAlreadyReported,
+	}
+}
+
+// NewErrAlreadyReportedWith returns a new ErrAlreadyReported with custom error message and key / value attributes
+func NewErrAlreadyReportedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusAlreadyReported,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrAlreadyReported returns a new ErrAlreadyReported, wrapping an existing error
 func WrapErrAlreadyReported(err error) error {
 	return &ErrorWithStatusCode{
@@ -97,6 +255,14 @@ func WrapErrAlreadyReported(err error) error {
 	}
 }
 
+// WrapErrAlreadyReportedf returns a new ErrAlreadyReported, wrapping an existing error with a formatted message
+func WrapErrAlreadyReportedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusAlreadyReported,
+	}
+}
+
 // ErrBadGateway is a StatusBadGateway Error
 var ErrBadGateway = ErrorWithStatusCode{statusCode: http.StatusBadGateway}
 
@@ -108,6 +274,23 @@ func NewErrBadGateway(message string) error {
 	}
 }
 
+// NewErrBadGatewayf returns a new ErrBadGateway with a formatted error message
+func NewErrBadGatewayf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusBadGateway,
+	}
+}
+
+// NewErrBadGatewayWith returns a new ErrBadGateway with custom error message and key / value attributes
+func NewErrBadGatewayWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusBadGateway,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrBadGateway returns a new ErrBadGateway, wrapping an existing error
 func WrapErrBadGateway(err error) error {
 	return &ErrorWithStatusCode{
@@ -116,6 +299,14 @@ func WrapErrBadGateway(err error) error {
 	}
 }
 
+// WrapErrBadGatewayf returns a new ErrBadGateway, wrapping an existing error with a formatted message
+func WrapErrBadGatewayf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusBadGateway,
+	}
+}
+
 // ErrBadRequest is a StatusBadRequest Error
 var ErrBadRequest = ErrorWithStatusCode{statusCode: http.StatusBadRequest}
 
@@ -127,6 +318,23 @@ func NewErrBadRequest(message string) error {
 	}
 }
 
+// NewErrBadRequestf returns a new ErrBadRequest with a formatted error message
+func NewErrBadRequestf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusBadRequest,
+	}
+}
+
+// NewErrBadRequestWith returns a new ErrBadRequest with custom error message and key / value attributes
+func NewErrBadRequestWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusBadRequest,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrBadRequest returns a new ErrBadRequest, wrapping an existing error
 func WrapErrBadRequest(err error) error {
 	return &ErrorWithStatusCode{
@@ -135,6 +343,58 @@ func WrapErrBadRequest(err error) error {
 	}
 }
 
+// WrapErrBadRequestf returns a new ErrBadRequest, wrapping an existing error with a formatted message
+func WrapErrBadRequestf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusBadRequest,
+	}
+}
+
+// ErrClientClosedRequest is a StatusClientClosedRequest Error
+var ErrClientClosedRequest = ErrorWithStatusCode{statusCode: StatusClientClosedRequest}
+
+// NewErrClientClosedRequest returns a new ErrClientClosedRequest with custom error message
+func NewErrClientClosedRequest(message string) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: StatusClientClosedRequest,
+	}
+}
+
+// NewErrClientClosedRequestf returns a new ErrClientClosedRequest with a formatted error message
+func NewErrClientClosedRequestf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: StatusClientClosedRequest,
+	}
+}
+
+// NewErrClientClosedRequestWith returns a new ErrClientClosedRequest with custom error message and key / value attributes
+func NewErrClientClosedRequestWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: StatusClientClosedRequest,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
+// WrapErrClientClosedRequest returns a new ErrClientClosedRequest, wrapping an existing error
+func WrapErrClientClosedRequest(err error) error {
+	return &ErrorWithStatusCode{
+		error:      err,
+		statusCode: StatusClientClosedRequest,
+	}
+}
+
+// WrapErrClientClosedRequestf returns a new ErrClientClosedRequest, wrapping an existing error with a formatted message
+func WrapErrClientClosedRequestf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: StatusClientClosedRequest,
+	}
+}
+
 // ErrConflict is a StatusConflict Error
 var ErrConflict = ErrorWithStatusCode{statusCode: http.StatusConflict}
 
@@ -146,6 +406,23 @@ func NewErrConflict(message string) error {
 	}
 }
 
+// NewErrConflictf returns a new ErrConflict with a formatted error message
+func NewErrConflictf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusConflict,
+	}
+}
+
+// NewErrConflictWith returns a new ErrConflict with custom error message and key / value attributes
+func NewErrConflictWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusConflict,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrConflict returns a new ErrConflict, wrapping an existing error
 func WrapErrConflict(err error) error {
 	return &ErrorWithStatusCode{
@@ -154,6 +431,14 @@ func WrapErrConflict(err error) error {
 	}
 }
 
+// WrapErrConflictf returns a new ErrConflict, wrapping an existing error with a formatted message
+func WrapErrConflictf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusConflict,
+	}
+}
+
 // ErrContinue is a StatusContinue Error
 var ErrContinue = ErrorWithStatusCode{statusCode: http.StatusContinue}
 
@@ -165,6 +450,23 @@ func NewErrContinue(message string) error {
 	}
 }
 
+// NewErrContinuef returns a new ErrContinue with a formatted error message
+func NewErrContinuef(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusContinue,
+	}
+}
+
+// NewErrContinueWith returns a new ErrContinue with custom error message and key / value attributes
+func NewErrContinueWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusContinue,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrContinue returns a new ErrContinue, wrapping an existing error
 func WrapErrContinue(err error) error {
 	return &ErrorWithStatusCode{
@@ -173,6 +475,14 @@ func WrapErrContinue(err error) error {
 	}
 }
 
+// WrapErrContinuef returns a new ErrContinue, wrapping an existing error with a formatted message
+func WrapErrContinuef(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusContinue,
+	}
+}
+
 // ErrCreated is a StatusCreated Error
 var ErrCreated = ErrorWithStatusCode{statusCode: http.StatusCreated}
 
@@ -184,6 +494,23 @@ func NewErrCreated(message string) error {
 	}
 }
 
+// NewErrCreatedf returns a new ErrCreated with a formatted error message
+func NewErrCreatedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusCreated,
+	}
+}
+
+// NewErrCreatedWith returns a new ErrCreated with custom error message and key / value attributes
+func NewErrCreatedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusCreated,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrCreated returns a new ErrCreated, wrapping an existing error
 func WrapErrCreated(err error) error {
 	return &ErrorWithStatusCode{
@@ -192,6 +519,14 @@ func WrapErrCreated(err error) error {
 	}
 }
 
+// WrapErrCreatedf returns a new ErrCreated, wrapping an existing error with a formatted message
+func WrapErrCreatedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusCreated,
+	}
+}
+
 // ErrEarlyHints is a StatusEarlyHints Error
 var ErrEarlyHints = ErrorWithStatusCode{statusCode: http.StatusEarlyHints}
 
@@ -203,6 +538,23 @@ func NewErrEarlyHints(message string) error {
 	}
 }
 
+// NewErrEarlyHintsf returns a new ErrEarlyHints with a formatted error message
+func NewErrEarlyHintsf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusEarlyHints,
+	}
+}
+
+// NewErrEarlyHintsWith returns a new ErrEarlyHints with custom error message and key / value attributes
+func NewErrEarlyHintsWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusEarlyHints,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrEarlyHints returns a new ErrEarlyHints, wrapping an existing error
 func WrapErrEarlyHints(err error) error {
 	return &ErrorWithStatusCode{
@@ -211,6 +563,14 @@ func WrapErrEarlyHints(err error) error {
 	}
 }
 
+// WrapErrEarlyHintsf returns a new ErrEarlyHints, wrapping an existing error with a formatted message
+func WrapErrEarlyHintsf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusEarlyHints,
+	}
+}
+
 // ErrExpectationFailed is a StatusExpectationFailed Error
 var ErrExpectationFailed = ErrorWithStatusCode{statusCode: http.StatusExpectationFailed}
 
@@ -222,6 +582,23 @@ func NewErrExpectationFailed(message string) error {
 	}
 }
 
+// NewErrExpectationFailedf returns a new ErrExpectationFailed with a formatted error message
+func NewErrExpectationFailedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusExpectationFailed,
+	}
+}
+
+// NewErrExpectationFailedWith returns a new ErrExpectationFailed with custom error message and key / value attributes
+func NewErrExpectationFailedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusExpectationFailed,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrExpectationFailed returns a new ErrExpectationFailed, wrapping an existing error
 func WrapErrExpectationFailed(err error) error {
 	return &ErrorWithStatusCode{
@@ -230,6 +607,14 @@ func WrapErrExpectationFailed(err error) error {
 	}
 }
 
+// WrapErrExpectationFailedf returns a new ErrExpectationFailed, wrapping an existing error with a formatted message
+func WrapErrExpectationFailedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusExpectationFailed,
+	}
+}
+
 // ErrFailedDependency is a StatusFailedDependency Error
 var ErrFailedDependency = ErrorWithStatusCode{statusCode: http.StatusFailedDependency}
 
@@ -241,6 +626,23 @@ func NewErrFailedDependency(message string) error {
 	}
 }
 
+// NewErrFailedDependencyf returns a new ErrFailedDependency with a formatted error message
+func NewErrFailedDependencyf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusFailedDependency,
+	}
+}
+
+// NewErrFailedDependencyWith returns a new ErrFailedDependency with custom error message and key / value attributes
+func NewErrFailedDependencyWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusFailedDependency,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrFailedDependency returns a new ErrFailedDependency, wrapping an existing error
 func WrapErrFailedDependency(err error) error {
 	return &ErrorWithStatusCode{
@@ -249,6 +651,14 @@ func WrapErrFailedDependency(err error) error {
 	}
 }
 
+// WrapErrFailedDependencyf returns a new ErrFailedDependency, wrapping an existing error with a formatted message
+func WrapErrFailedDependencyf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusFailedDependency,
+	}
+}
+
 // ErrForbidden is a StatusForbidden Error
 var ErrForbidden = ErrorWithStatusCode{statusCode: http.StatusForbidden}
 
@@ -260,6 +670,23 @@ func NewErrForbidden(message string) error {
 	}
 }
 
+// NewErrForbiddenf returns a new ErrForbidden with a formatted error message
+func NewErrForbiddenf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusForbidden,
+	}
+}
+
+// NewErrForbiddenWith returns a new ErrForbidden with custom error message and key / value attributes
+func NewErrForbiddenWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusForbidden,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrForbidden returns a new ErrForbidden, wrapping an existing error
 func WrapErrForbidden(err error) error {
 	return &ErrorWithStatusCode{
@@ -268,6 +695,14 @@ func WrapErrForbidden(err error) error {
 	}
 }
 
+// WrapErrForbiddenf returns a new ErrForbidden, wrapping an existing error with a formatted message
+func WrapErrForbiddenf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusForbidden,
+	}
+}
+
 // ErrFound is a StatusFound Error
 var ErrFound = ErrorWithStatusCode{statusCode: http.StatusFound}
 
@@ -279,29 +714,79 @@ func NewErrFound(message string) error {
 	}
 }
 
-// WrapErrFound returns a new ErrFound, wrapping an existing error
-func WrapErrFound(err error) error {
+// NewErrFoundf returns a new ErrFound with a formatted error message
+func NewErrFoundf(format string, args ...interface{}) error {
 	return &ErrorWithStatusCode{
-		error:      err,
+		error:      fmt.Errorf(format, args...),
 		statusCode: http.StatusFound,
 	}
 }
 
-// ErrGatewayTimeout is a StatusGatewayTimeout Error
-var ErrGatewayTimeout = ErrorWithStatusCode{statusCode: http.StatusGatewayTimeout}
-
-// NewErrGatewayTimeout returns a new ErrGatewayTimeout with custom error message
-func NewErrGatewayTimeout(message string) error {
+// NewErrFoundWith returns a new ErrFound with custom error message and key / value attributes
+func NewErrFoundWith(message string, attributes ...interface{}) error {
 	return &ErrorWithStatusCode{
 		error:      errors.New(message),
-		statusCode: http.StatusGatewayTimeout,
+		statusCode: http.StatusFound,
+		details:    &errorDetails{attributes: attributes},
 	}
 }
 
-// WrapErrGatewayTimeout returns a new ErrGatewayTimeout, wrapping an existing error
-func WrapErrGatewayTimeout(err error) error {
+// WrapErrFound returns a new ErrFound, wrapping an existing error
+func WrapErrFound(err error) error {
 	return &ErrorWithStatusCode{
 		error:      err,
+		statusCode: http.StatusFound,
+	}
+}
+
+// WrapErrFoundf returns a new ErrFound, wrapping an existing error with a formatted message
+func WrapErrFoundf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusFound,
+	}
+}
+
+// ErrGatewayTimeout is a StatusGatewayTimeout Error
+var ErrGatewayTimeout = ErrorWithStatusCode{statusCode: http.StatusGatewayTimeout}
+
+// NewErrGatewayTimeout returns a new ErrGatewayTimeout with custom error message
+func NewErrGatewayTimeout(message string) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusGatewayTimeout,
+	}
+}
+
+// NewErrGatewayTimeoutf returns a new ErrGatewayTimeout with a formatted error message
+func NewErrGatewayTimeoutf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusGatewayTimeout,
+	}
+}
+
+// NewErrGatewayTimeoutWith returns a new ErrGatewayTimeout with custom error message and key / value attributes
+func NewErrGatewayTimeoutWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusGatewayTimeout,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
+// WrapErrGatewayTimeout returns a new ErrGatewayTimeout, wrapping an existing error
+func WrapErrGatewayTimeout(err error) error {
+	return &ErrorWithStatusCode{
+		error:      err,
+		statusCode: http.StatusGatewayTimeout,
+	}
+}
+
+// WrapErrGatewayTimeoutf returns a new ErrGatewayTimeout, wrapping an existing error with a formatted message
+func WrapErrGatewayTimeoutf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
 		statusCode: http.StatusGatewayTimeout,
 	}
 }
@@ -317,6 +802,23 @@ func NewErrGone(message string) error {
 	}
 }
 
+// NewErrGonef returns a new ErrGone with a formatted error message
+func NewErrGonef(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusGone,
+	}
+}
+
+// NewErrGoneWith returns a new ErrGone with custom error message and key / value attributes
+func NewErrGoneWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusGone,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrGone returns a new ErrGone, wrapping an existing error
 func WrapErrGone(err error) error {
 	return &ErrorWithStatusCode{
@@ -325,6 +827,14 @@ func WrapErrGone(err error) error {
 	}
 }
 
+// WrapErrGonef returns a new ErrGone, wrapping an existing error with a formatted message
+func WrapErrGonef(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusGone,
+	}
+}
+
 // ErrHTTPVersionNotSupported is a StatusHTTPVersionNotSupported Error
 var ErrHTTPVersionNotSupported = ErrorWithStatusCode{statusCode: http.StatusHTTPVersionNotSupported}
 
@@ -336,6 +846,23 @@ func NewErrHTTPVersionNotSupported(message string) error {
 	}
 }
 
+// NewErrHTTPVersionNotSupportedf returns a new ErrHTTPVersionNotSupported with a formatted error message
+func NewErrHTTPVersionNotSupportedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusHTTPVersionNotSupported,
+	}
+}
+
+// NewErrHTTPVersionNotSupportedWith returns a new ErrHTTPVersionNotSupported with custom error message and key / value attributes
+func NewErrHTTPVersionNotSupportedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusHTTPVersionNotSupported,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrHTTPVersionNotSupported returns a new ErrHTTPVersionNotSupported, wrapping an existing error
 func WrapErrHTTPVersionNotSupported(err error) error {
 	return &ErrorWithStatusCode{
@@ -344,6 +871,14 @@ func WrapErrHTTPVersionNotSupported(err error) error {
 	}
 }
 
+// WrapErrHTTPVersionNotSupportedf returns a new ErrHTTPVersionNotSupported, wrapping an existing error with a formatted message
+func WrapErrHTTPVersionNotSupportedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusHTTPVersionNotSupported,
+	}
+}
+
 // ErrIMUsed is a StatusIMUsed Error
 var ErrIMUsed = ErrorWithStatusCode{statusCode: http.StatusIMUsed}
 
@@ -355,6 +890,23 @@ func NewErrIMUsed(message string) error {
 	}
 }
 
+// NewErrIMUsedf returns a new ErrIMUsed with a formatted error message
+func NewErrIMUsedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusIMUsed,
+	}
+}
+
+// NewErrIMUsedWith returns a new ErrIMUsed with custom error message and key / value attributes
+func NewErrIMUsedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusIMUsed,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrIMUsed returns a new ErrIMUsed, wrapping an existing error
 func WrapErrIMUsed(err error) error {
 	return &ErrorWithStatusCode{
@@ -363,6 +915,14 @@ func WrapErrIMUsed(err error) error {
 	}
 }
 
+// WrapErrIMUsedf returns a new ErrIMUsed, wrapping an existing error with a formatted message
+func WrapErrIMUsedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusIMUsed,
+	}
+}
+
 // ErrInsufficientStorage is a StatusInsufficientStorage Error
 var ErrInsufficientStorage = ErrorWithStatusCode{statusCode: http.StatusInsufficientStorage}
 
@@ -374,6 +934,23 @@ func NewErrInsufficientStorage(message string) error {
 	}
 }
 
+// NewErrInsufficientStoragef returns a new ErrInsufficientStorage with a formatted error message
+func NewErrInsufficientStoragef(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusInsufficientStorage,
+	}
+}
+
+// NewErrInsufficientStorageWith returns a new ErrInsufficientStorage with custom error message and key / value attributes
+func NewErrInsufficientStorageWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusInsufficientStorage,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrInsufficientStorage returns a new ErrInsufficientStorage, wrapping an existing error
 func WrapErrInsufficientStorage(err error) error {
 	return &ErrorWithStatusCode{
@@ -382,6 +959,14 @@ func WrapErrInsufficientStorage(err error) error {
 	}
 }
 
+// WrapErrInsufficientStoragef returns a new ErrInsufficientStorage, wrapping an existing error with a formatted message
+func WrapErrInsufficientStoragef(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusInsufficientStorage,
+	}
+}
+
 // ErrInternalServerError is a StatusInternalServerError Error
 var ErrInternalServerError = ErrorWithStatusCode{statusCode: http.StatusInternalServerError}
 
@@ -393,6 +978,23 @@ func NewErrInternalServerError(message string) error {
 	}
 }
 
+// NewErrInternalServerErrorf returns a new ErrInternalServerError with a formatted error message
+func NewErrInternalServerErrorf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusInternalServerError,
+	}
+}
+
+// NewErrInternalServerErrorWith returns a new ErrInternalServerError with custom error message and key / value attributes
+func NewErrInternalServerErrorWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusInternalServerError,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrInternalServerError returns a new ErrInternalServerError, wrapping an existing error
 func WrapErrInternalServerError(err error) error {
 	return &ErrorWithStatusCode{
@@ -401,6 +1003,14 @@ func WrapErrInternalServerError(err error) error {
 	}
 }
 
+// WrapErrInternalServerErrorf returns a new ErrInternalServerError, wrapping an existing error with a formatted message
+func WrapErrInternalServerErrorf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusInternalServerError,
+	}
+}
+
 // ErrLengthRequired is a StatusLengthRequired Error
 var ErrLengthRequired = ErrorWithStatusCode{statusCode: http.StatusLengthRequired}
 
@@ -412,6 +1022,23 @@ func NewErrLengthRequired(message string) error {
 	}
 }
 
+// NewErrLengthRequiredf returns a new ErrLengthRequired with a formatted error message
+func NewErrLengthRequiredf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusLengthRequired,
+	}
+}
+
+// NewErrLengthRequiredWith returns a new ErrLengthRequired with custom error message and key / value attributes
+func NewErrLengthRequiredWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusLengthRequired,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrLengthRequired returns a new ErrLengthRequired, wrapping an existing error
 func WrapErrLengthRequired(err error) error {
 	return &ErrorWithStatusCode{
@@ -420,6 +1047,14 @@ func WrapErrLengthRequired(err error) error {
 	}
 }
 
+// WrapErrLengthRequiredf returns a new ErrLengthRequired, wrapping an existing error with a formatted message
+func WrapErrLengthRequiredf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusLengthRequired,
+	}
+}
+
 // ErrLocked is a StatusLocked Error
 var ErrLocked = ErrorWithStatusCode{statusCode: http.StatusLocked}
 
@@ -431,6 +1066,23 @@ func NewErrLocked(message string) error {
 	}
 }
 
+// NewErrLockedf returns a new ErrLocked with a formatted error message
+func NewErrLockedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusLocked,
+	}
+}
+
+// NewErrLockedWith returns a new ErrLocked with custom error message and key / value attributes
+func NewErrLockedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusLocked,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrLocked returns a new ErrLocked, wrapping an existing error
 func WrapErrLocked(err error) error {
 	return &ErrorWithStatusCode{
@@ -439,6 +1091,14 @@ func WrapErrLocked(err error) error {
 	}
 }
 
+// WrapErrLockedf returns a new ErrLocked, wrapping an existing error with a formatted message
+func WrapErrLockedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusLocked,
+	}
+}
+
 // ErrLoopDetected is a StatusLoopDetected Error
 var ErrLoopDetected = ErrorWithStatusCode{statusCode: http.StatusLoopDetected}
 
@@ -450,6 +1110,23 @@ func NewErrLoopDetected(message string) error {
 	}
 }
 
+// NewErrLoopDetectedf returns a new ErrLoopDetected with a formatted error message
+func NewErrLoopDetectedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusLoopDetected,
+	}
+}
+
+// NewErrLoopDetectedWith returns a new ErrLoopDetected with custom error message and key / value attributes
+func NewErrLoopDetectedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusLoopDetected,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrLoopDetected returns a new ErrLoopDetected, wrapping an existing error
 func WrapErrLoopDetected(err error) error {
 	return &ErrorWithStatusCode{
@@ -458,6 +1135,14 @@ func WrapErrLoopDetected(err error) error {
 	}
 }
 
+// WrapErrLoopDetectedf returns a new ErrLoopDetected, wrapping an existing error with a formatted message
+func WrapErrLoopDetectedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusLoopDetected,
+	}
+}
+
 // ErrMethodNotAllowed is a StatusMethodNotAllowed Error
 var ErrMethodNotAllowed = ErrorWithStatusCode{statusCode: http.StatusMethodNotAllowed}
 
@@ -469,6 +1154,23 @@ func NewErrMethodNotAllowed(message string) error {
 	}
 }
 
+// NewErrMethodNotAllowedf returns a new ErrMethodNotAllowed with a formatted error message
+func NewErrMethodNotAllowedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusMethodNotAllowed,
+	}
+}
+
+// NewErrMethodNotAllowedWith returns a new ErrMethodNotAllowed with custom error message and key / value attributes
+func NewErrMethodNotAllowedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusMethodNotAllowed,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrMethodNotAllowed returns a new ErrMethodNotAllowed, wrapping an existing error
 func WrapErrMethodNotAllowed(err error) error {
 	return &ErrorWithStatusCode{
@@ -477,6 +1179,14 @@ func WrapErrMethodNotAllowed(err error) error {
 	}
 }
 
+// WrapErrMethodNotAllowedf returns a new ErrMethodNotAllowed, wrapping an existing error with a formatted message
+func WrapErrMethodNotAllowedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusMethodNotAllowed,
+	}
+}
+
 // ErrMisdirectedRequest is a StatusMisdirectedRequest Error
 var ErrMisdirectedRequest = ErrorWithStatusCode{statusCode: http.StatusMisdirectedRequest}
 
@@ -488,6 +1198,23 @@ func NewErrMisdirectedRequest(message string) error {
 	}
 }
 
+// NewErrMisdirectedRequestf returns a new ErrMisdirectedRequest with a formatted error message
+func NewErrMisdirectedRequestf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusMisdirectedRequest,
+	}
+}
+
+// NewErrMisdirectedRequestWith returns a new ErrMisdirectedRequest with custom error message and key / value attributes
+func NewErrMisdirectedRequestWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusMisdirectedRequest,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrMisdirectedRequest returns a new ErrMisdirectedRequest, wrapping an existing error
 func WrapErrMisdirectedRequest(err error) error {
 	return &ErrorWithStatusCode{
@@ -496,6 +1223,14 @@ func WrapErrMisdirectedRequest(err error) error {
 	}
 }
 
+// WrapErrMisdirectedRequestf returns a new ErrMisdirectedRequest, wrapping an existing error with a formatted message
+func WrapErrMisdirectedRequestf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusMisdirectedRequest,
+	}
+}
+
 // ErrMovedPermanently is a StatusMovedPermanently Error
 var ErrMovedPermanently = ErrorWithStatusCode{statusCode: http.StatusMovedPermanently}
 
@@ -507,6 +1242,23 @@ func NewErrMovedPermanently(message string) error {
 	}
 }
 
+// NewErrMovedPermanentlyf returns a new ErrMovedPermanently with a formatted error message
+func NewErrMovedPermanentlyf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusMovedPermanently,
+	}
+}
+
+// NewErrMovedPermanentlyWith returns a new ErrMovedPermanently with custom error message and key / value attributes
+func NewErrMovedPermanentlyWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusMovedPermanently,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrMovedPermanently returns a new ErrMovedPermanently, wrapping an existing error
 func WrapErrMovedPermanently(err error) error {
 	return &ErrorWithStatusCode{
@@ -515,6 +1267,14 @@ func WrapErrMovedPermanently(err error) error {
 	}
 }
 
+// WrapErrMovedPermanentlyf returns a new ErrMovedPermanently, wrapping an existing error with a formatted message
+func WrapErrMovedPermanentlyf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusMovedPermanently,
+	}
+}
+
 // ErrMultiStatus is a StatusMultiStatus Error
 var ErrMultiStatus = ErrorWithStatusCode{statusCode: http.StatusMultiStatus}
 
@@ -526,6 +1286,23 @@ func NewErrMultiStatus(message string) error {
 	}
 }
 
+// NewErrMultiStatusf returns a new ErrMultiStatus with a formatted error message
+func NewErrMultiStatusf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusMultiStatus,
+	}
+}
+
+// NewErrMultiStatusWith returns a new ErrMultiStatus with custom error message and key / value attributes
+func NewErrMultiStatusWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusMultiStatus,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrMultiStatus returns a new ErrMultiStatus, wrapping an existing error
 func WrapErrMultiStatus(err error) error {
 	return &ErrorWithStatusCode{
@@ -534,6 +1311,14 @@ func WrapErrMultiStatus(err error) error {
 	}
 }
 
+// WrapErrMultiStatusf returns a new ErrMultiStatus, wrapping an existing error with a formatted message
+func WrapErrMultiStatusf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusMultiStatus,
+	}
+}
+
 // ErrMultipleChoices is a StatusMultipleChoices Error
 var ErrMultipleChoices = ErrorWithStatusCode{statusCode: http.StatusMultipleChoices}
 
@@ -545,6 +1330,23 @@ func NewErrMultipleChoices(message string) error {
 	}
 }
 
+// NewErrMultipleChoicesf returns a new ErrMultipleChoices with a formatted error message
+func NewErrMultipleChoicesf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusMultipleChoices,
+	}
+}
+
+// NewErrMultipleChoicesWith returns a new ErrMultipleChoices with custom error message and key / value attributes
+func NewErrMultipleChoicesWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusMultipleChoices,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrMultipleChoices returns a new ErrMultipleChoices, wrapping an existing error
 func WrapErrMultipleChoices(err error) error {
 	return &ErrorWithStatusCode{
@@ -553,6 +1355,14 @@ func WrapErrMultipleChoices(err error) error {
 	}
 }
 
+// WrapErrMultipleChoicesf returns a new ErrMultipleChoices, wrapping an existing error with a formatted message
+func WrapErrMultipleChoicesf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusMultipleChoices,
+	}
+}
+
 // ErrNetworkAuthenticationRequired is a StatusNetworkAuthenticationRequired Error
 var ErrNetworkAuthenticationRequired = ErrorWithStatusCode{statusCode: http.StatusNetworkAuthenticationRequired}
 
@@ -564,6 +1374,23 @@ func NewErrNetworkAuthenticationRequired(message string) error {
 	}
 }
 
+// NewErrNetworkAuthenticationRequiredf returns a new ErrNetworkAuthenticationRequired with a formatted error message
+func NewErrNetworkAuthenticationRequiredf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusNetworkAuthenticationRequired,
+	}
+}
+
+// NewErrNetworkAuthenticationRequiredWith returns a new ErrNetworkAuthenticationRequired with custom error message and key / value attributes
+func NewErrNetworkAuthenticationRequiredWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusNetworkAuthenticationRequired,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrNetworkAuthenticationRequired returns a new ErrNetworkAuthenticationRequired, wrapping an existing error
 func WrapErrNetworkAuthenticationRequired(err error) error {
 	return &ErrorWithStatusCode{
@@ -572,6 +1399,102 @@ func WrapErrNetworkAuthenticationRequired(err error) error {
 	}
 }
 
+// WrapErrNetworkAuthenticationRequiredf returns a new ErrNetworkAuthenticationRequired, wrapping an existing error with a formatted message
+func WrapErrNetworkAuthenticationRequiredf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusNetworkAuthenticationRequired,
+	}
+}
+
+// ErrNetworkConnectTimeout is a StatusNetworkConnectTimeout Error
+var ErrNetworkConnectTimeout = ErrorWithStatusCode{statusCode: StatusNetworkConnectTimeout}
+
+// NewErrNetworkConnectTimeout returns a new ErrNetworkConnectTimeout with custom error message
+func NewErrNetworkConnectTimeout(message string) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: StatusNetworkConnectTimeout,
+	}
+}
+
+// NewErrNetworkConnectTimeoutf returns a new ErrNetworkConnectTimeout with a formatted error message
+func NewErrNetworkConnectTimeoutf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: StatusNetworkConnectTimeout,
+	}
+}
+
+// NewErrNetworkConnectTimeoutWith returns a new ErrNetworkConnectTimeout with custom error message and key / value attributes
+func NewErrNetworkConnectTimeoutWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: StatusNetworkConnectTimeout,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
+// WrapErrNetworkConnectTimeout returns a new ErrNetworkConnectTimeout, wrapping an existing error
+func WrapErrNetworkConnectTimeout(err error) error {
+	return &ErrorWithStatusCode{
+		error:      err,
+		statusCode: StatusNetworkConnectTimeout,
+	}
+}
+
+// WrapErrNetworkConnectTimeoutf returns a new ErrNetworkConnectTimeout, wrapping an existing error with a formatted message
+func WrapErrNetworkConnectTimeoutf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: StatusNetworkConnectTimeout,
+	}
+}
+
+// ErrNetworkReadTimeout is a StatusNetworkReadTimeout Error
+var ErrNetworkReadTimeout = ErrorWithStatusCode{statusCode: StatusNetworkReadTimeout}
+
+// NewErrNetworkReadTimeout returns a new ErrNetworkReadTimeout with custom error message
+func NewErrNetworkReadTimeout(message string) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: StatusNetworkReadTimeout,
+	}
+}
+
+// NewErrNetworkReadTimeoutf returns a new ErrNetworkReadTimeout with a formatted error message
+func NewErrNetworkReadTimeoutf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: StatusNetworkReadTimeout,
+	}
+}
+
+// NewErrNetworkReadTimeoutWith returns a new ErrNetworkReadTimeout with custom error message and key / value attributes
+func NewErrNetworkReadTimeoutWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: StatusNetworkReadTimeout,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
+// WrapErrNetworkReadTimeout returns a new ErrNetworkReadTimeout, wrapping an existing error
+func WrapErrNetworkReadTimeout(err error) error {
+	return &ErrorWithStatusCode{
+		error:      err,
+		statusCode: StatusNetworkReadTimeout,
+	}
+}
+
+// WrapErrNetworkReadTimeoutf returns a new ErrNetworkReadTimeout, wrapping an existing error with a formatted message
+func WrapErrNetworkReadTimeoutf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: StatusNetworkReadTimeout,
+	}
+}
+
 // ErrNoContent is a StatusNoContent Error
 var ErrNoContent = ErrorWithStatusCode{statusCode: http.StatusNoContent}
 
@@ -583,6 +1506,23 @@ func NewErrNoContent(message string) error {
 	}
 }
 
+// NewErrNoContentf returns a new ErrNoContent with a formatted error message
+func NewErrNoContentf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusNoContent,
+	}
+}
+
+// NewErrNoContentWith returns a new ErrNoContent with custom error message and key / value attributes
+func NewErrNoContentWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusNoContent,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrNoContent returns a new ErrNoContent, wrapping an existing error
 func WrapErrNoContent(err error) error {
 	return &ErrorWithStatusCode{
@@ -591,6 +1531,14 @@ func WrapErrNoContent(err error) error {
 	}
 }
 
+// WrapErrNoContentf returns a new ErrNoContent, wrapping an existing error with a formatted message
+func WrapErrNoContentf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusNoContent,
+	}
+}
+
 // ErrNonAuthoritativeInfo is a StatusNonAuthoritativeInfo Error
 var ErrNonAuthoritativeInfo = ErrorWithStatusCode{statusCode: http.StatusNonAuthoritativeInfo}
 
@@ -602,6 +1550,23 @@ func NewErrNonAuthoritativeInfo(message string) error {
 	}
 }
 
+// NewErrNonAuthoritativeInfof returns a new ErrNonAuthoritativeInfo with a formatted error message
+func NewErrNonAuthoritativeInfof(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusNonAuthoritativeInfo,
+	}
+}
+
+// NewErrNonAuthoritativeInfoWith returns a new ErrNonAuthoritativeInfo with custom error message and key / value attributes
+func NewErrNonAuthoritativeInfoWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusNonAuthoritativeInfo,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrNonAuthoritativeInfo returns a new ErrNonAuthoritativeInfo, wrapping an existing error
 func WrapErrNonAuthoritativeInfo(err error) error {
 	return &ErrorWithStatusCode{
@@ -610,6 +1575,14 @@ func WrapErrNonAuthoritativeInfo(err error) error {
 	}
 }
 
+// WrapErrNonAuthoritativeInfof returns a new ErrNonAuthoritativeInfo, wrapping an existing error with a formatted message
+func WrapErrNonAuthoritativeInfof(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusNonAuthoritativeInfo,
+	}
+}
+
 // ErrNotAcceptable is a StatusNotAcceptable Error
 var ErrNotAcceptable = ErrorWithStatusCode{statusCode: http.StatusNotAcceptable}
 
@@ -621,6 +1594,23 @@ func NewErrNotAcceptable(message string) error {
 	}
 }
 
+// NewErrNotAcceptablef returns a new ErrNotAcceptable with a formatted error message
+func NewErrNotAcceptablef(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusNotAcceptable,
+	}
+}
+
+// NewErrNotAcceptableWith returns a new ErrNotAcceptable with custom error message and key / value attributes
+func NewErrNotAcceptableWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusNotAcceptable,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrNotAcceptable returns a new ErrNotAcceptable, wrapping an existing error
 func WrapErrNotAcceptable(err error) error {
 	return &ErrorWithStatusCode{
@@ -629,6 +1619,14 @@ func WrapErrNotAcceptable(err error) error {
 	}
 }
 
+// WrapErrNotAcceptablef returns a new ErrNotAcceptable, wrapping an existing error with a formatted message
+func WrapErrNotAcceptablef(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusNotAcceptable,
+	}
+}
+
 // ErrNotExtended is a StatusNotExtended Error
 var ErrNotExtended = ErrorWithStatusCode{statusCode: http.StatusNotExtended}
 
@@ -640,6 +1638,23 @@ func NewErrNotExtended(message string) error {
 	}
 }
 
+// NewErrNotExtendedf returns a new ErrNotExtended with a formatted error message
+func NewErrNotExtendedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusNotExtended,
+	}
+}
+
+// NewErrNotExtendedWith returns a new ErrNotExtended with custom error message and key / value attributes
+func NewErrNotExtendedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusNotExtended,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrNotExtended returns a new ErrNotExtended, wrapping an existing error
 func WrapErrNotExtended(err error) error {
 	return &ErrorWithStatusCode{
@@ -648,6 +1663,14 @@ func WrapErrNotExtended(err error) error {
 	}
 }
 
+// WrapErrNotExtendedf returns a new ErrNotExtended, wrapping an existing error with a formatted message
+func WrapErrNotExtendedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusNotExtended,
+	}
+}
+
 // ErrNotFound is a StatusNotFound Error
 var ErrNotFound = ErrorWithStatusCode{statusCode: http.StatusNotFound}
 
@@ -659,6 +1682,23 @@ func NewErrNotFound(message string) error {
 	}
 }
 
+// NewErrNotFoundf returns a new ErrNotFound with a formatted error message
+func NewErrNotFoundf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusNotFound,
+	}
+}
+
+// NewErrNotFoundWith returns a new ErrNotFound with custom error message and key / value attributes
+func NewErrNotFoundWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusNotFound,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrNotFound returns a new ErrNotFound, wrapping an existing error
 func WrapErrNotFound(err error) error {
 	return &ErrorWithStatusCode{
@@ -667,6 +1707,14 @@ func WrapErrNotFound(err error) error {
 	}
 }
 
+// WrapErrNotFoundf returns a new ErrNotFound, wrapping an existing error with a formatted message
+func WrapErrNotFoundf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusNotFound,
+	}
+}
+
 // ErrNotImplemented is a StatusNotImplemented Error
 var ErrNotImplemented = ErrorWithStatusCode{statusCode: http.StatusNotImplemented}
 
@@ -678,6 +1726,23 @@ func NewErrNotImplemented(message string) error {
 	}
 }
 
+// NewErrNotImplementedf returns a new ErrNotImplemented with a formatted error message
+func NewErrNotImplementedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusNotImplemented,
+	}
+}
+
+// NewErrNotImplementedWith returns a new ErrNotImplemented with custom error message and key / value attributes
+func NewErrNotImplementedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusNotImplemented,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrNotImplemented returns a new ErrNotImplemented, wrapping an existing error
 func WrapErrNotImplemented(err error) error {
 	return &ErrorWithStatusCode{
@@ -686,6 +1751,14 @@ func WrapErrNotImplemented(err error) error {
 	}
 }
 
+// WrapErrNotImplementedf returns a new ErrNotImplemented, wrapping an existing error with a formatted message
+func WrapErrNotImplementedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusNotImplemented,
+	}
+}
+
 // ErrNotModified is a StatusNotModified Error
 var ErrNotModified = ErrorWithStatusCode{statusCode: http.StatusNotModified}
 
@@ -697,6 +1770,23 @@ func NewErrNotModified(message string) error {
 	}
 }
 
+// NewErrNotModifiedf returns a new ErrNotModified with a formatted error message
+func NewErrNotModifiedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusNotModified,
+	}
+}
+
+// NewErrNotModifiedWith returns a new ErrNotModified with custom error message and key / value attributes
+func NewErrNotModifiedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusNotModified,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrNotModified returns a new ErrNotModified, wrapping an existing error
 func WrapErrNotModified(err error) error {
 	return &ErrorWithStatusCode{
@@ -705,6 +1795,14 @@ func WrapErrNotModified(err error) error {
 	}
 }
 
+// WrapErrNotModifiedf returns a new ErrNotModified, wrapping an existing error with a formatted message
+func WrapErrNotModifiedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusNotModified,
+	}
+}
+
 // ErrPartialContent is a StatusPartialContent Error
 var ErrPartialContent = ErrorWithStatusCode{statusCode: http.StatusPartialContent}
 
@@ -716,6 +1814,23 @@ func NewErrPartialContent(message string) error {
 	}
 }
 
+// NewErrPartialContentf returns a new ErrPartialContent with a formatted error message
+func NewErrPartialContentf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusPartialContent,
+	}
+}
+
+// NewErrPartialContentWith returns a new ErrPartialContent with custom error message and key / value attributes
+func NewErrPartialContentWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusPartialContent,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrPartialContent returns a new ErrPartialContent, wrapping an existing error
 func WrapErrPartialContent(err error) error {
 	return &ErrorWithStatusCode{
@@ -724,6 +1839,14 @@ func WrapErrPartialContent(err error) error {
 	}
 }
 
+// WrapErrPartialContentf returns a new ErrPartialContent, wrapping an existing error with a formatted message
+func WrapErrPartialContentf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusPartialContent,
+	}
+}
+
 // ErrPaymentRequired is a StatusPaymentRequired Error
 var ErrPaymentRequired = ErrorWithStatusCode{statusCode: http.StatusPaymentRequired}
 
@@ -735,6 +1858,23 @@ func NewErrPaymentRequired(message string) error {
 	}
 }
 
+// NewErrPaymentRequiredf returns a new ErrPaymentRequired with a formatted error message
+func NewErrPaymentRequiredf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusPaymentRequired,
+	}
+}
+
+// NewErrPaymentRequiredWith returns a new ErrPaymentRequired with custom error message and key / value attributes
+func NewErrPaymentRequiredWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusPaymentRequired,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrPaymentRequired returns a new ErrPaymentRequired, wrapping an existing error
 func WrapErrPaymentRequired(err error) error {
 	return &ErrorWithStatusCode{
@@ -743,6 +1883,14 @@ func WrapErrPaymentRequired(err error) error {
 	}
 }
 
+// WrapErrPaymentRequiredf returns a new ErrPaymentRequired, wrapping an existing error with a formatted message
+func WrapErrPaymentRequiredf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusPaymentRequired,
+	}
+}
+
 // ErrPermanentRedirect is a StatusPermanentRedirect Error
 var ErrPermanentRedirect = ErrorWithStatusCode{statusCode: http.StatusPermanentRedirect}
 
@@ -754,6 +1902,23 @@ func NewErrPermanentRedirect(message string) error {
 	}
 }
 
+// NewErrPermanentRedirectf returns a new ErrPermanentRedirect with a formatted error message
+func NewErrPermanentRedirectf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusPermanentRedirect,
+	}
+}
+
+// NewErrPermanentRedirectWith returns a new ErrPermanentRedirect with custom error message and key / value attributes
+func NewErrPermanentRedirectWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusPermanentRedirect,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrPermanentRedirect returns a new ErrPermanentRedirect, wrapping an existing error
 func WrapErrPermanentRedirect(err error) error {
 	return &ErrorWithStatusCode{
@@ -762,6 +1927,14 @@ func WrapErrPermanentRedirect(err error) error {
 	}
 }
 
+// WrapErrPermanentRedirectf returns a new ErrPermanentRedirect, wrapping an existing error with a formatted message
+func WrapErrPermanentRedirectf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusPermanentRedirect,
+	}
+}
+
 // ErrPreconditionFailed is a StatusPreconditionFailed Error
 var ErrPreconditionFailed = ErrorWithStatusCode{statusCode: http.StatusPreconditionFailed}
 
@@ -773,6 +1946,23 @@ func NewErrPreconditionFailed(message string) error {
 	}
 }
 
+// NewErrPreconditionFailedf returns a new ErrPreconditionFailed with a formatted error message
+func NewErrPreconditionFailedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusPreconditionFailed,
+	}
+}
+
+// NewErrPreconditionFailedWith returns a new ErrPreconditionFailed with custom error message and key / value attributes
+func NewErrPreconditionFailedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusPreconditionFailed,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrPreconditionFailed returns a new ErrPreconditionFailed, wrapping an existing error
 func WrapErrPreconditionFailed(err error) error {
 	return &ErrorWithStatusCode{
@@ -781,6 +1971,14 @@ func WrapErrPreconditionFailed(err error) error {
 	}
 }
 
+// WrapErrPreconditionFailedf returns a new ErrPreconditionFailed, wrapping an existing error with a formatted message
+func WrapErrPreconditionFailedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusPreconditionFailed,
+	}
+}
+
 // ErrPreconditionRequired is a StatusPreconditionRequired Error
 var ErrPreconditionRequired = ErrorWithStatusCode{statusCode: http.StatusPreconditionRequired}
 
@@ -792,6 +1990,23 @@ func NewErrPreconditionRequired(message string) error {
 	}
 }
 
+// NewErrPreconditionRequiredf returns a new ErrPreconditionRequired with a formatted error message
+func NewErrPreconditionRequiredf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusPreconditionRequired,
+	}
+}
+
+// NewErrPreconditionRequiredWith returns a new ErrPreconditionRequired with custom error message and key / value attributes
+func NewErrPreconditionRequiredWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusPreconditionRequired,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrPreconditionRequired returns a new ErrPreconditionRequired, wrapping an existing error
 func WrapErrPreconditionRequired(err error) error {
 	return &ErrorWithStatusCode{
@@ -800,6 +2015,14 @@ func WrapErrPreconditionRequired(err error) error {
 	}
 }
 
+// WrapErrPreconditionRequiredf returns a new ErrPreconditionRequired, wrapping an existing error with a formatted message
+func WrapErrPreconditionRequiredf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusPreconditionRequired,
+	}
+}
+
 // ErrProcessing is a StatusProcessing Error
 var ErrProcessing = ErrorWithStatusCode{statusCode: http.StatusProcessing}
 
@@ -811,6 +2034,23 @@ func NewErrProcessing(message string) error {
 	}
 }
 
+// NewErrProcessingf returns a new ErrProcessing with a formatted error message
+func NewErrProcessingf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusProcessing,
+	}
+}
+
+// NewErrProcessingWith returns a new ErrProcessing with custom error message and key / value attributes
+func NewErrProcessingWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusProcessing,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrProcessing returns a new ErrProcessing, wrapping an existing error
 func WrapErrProcessing(err error) error {
 	return &ErrorWithStatusCode{
@@ -819,6 +2059,14 @@ func WrapErrProcessing(err error) error {
 	}
 }
 
+// WrapErrProcessingf returns a new ErrProcessing, wrapping an existing error with a formatted message
+func WrapErrProcessingf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusProcessing,
+	}
+}
+
 // ErrProxyAuthRequired is a StatusProxyAuthRequired Error
 var ErrProxyAuthRequired = ErrorWithStatusCode{statusCode: http.StatusProxyAuthRequired}
 
@@ -830,6 +2078,23 @@ func NewErrProxyAuthRequired(message string) error {
 	}
 }
 
+// NewErrProxyAuthRequiredf returns a new ErrProxyAuthRequired with a formatted error message
+func NewErrProxyAuthRequiredf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusProxyAuthRequired,
+	}
+}
+
+// NewErrProxyAuthRequiredWith returns a new ErrProxyAuthRequired with custom error message and key / value attributes
+func NewErrProxyAuthRequiredWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusProxyAuthRequired,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrProxyAuthRequired returns a new ErrProxyAuthRequired, wrapping an existing error
 func WrapErrProxyAuthRequired(err error) error {
 	return &ErrorWithStatusCode{
@@ -838,6 +2103,14 @@ func WrapErrProxyAuthRequired(err error) error {
 	}
 }
 
+// WrapErrProxyAuthRequiredf returns a new ErrProxyAuthRequired, wrapping an existing error with a formatted message
+func WrapErrProxyAuthRequiredf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusProxyAuthRequired,
+	}
+}
+
 // ErrRequestEntityTooLarge is a StatusRequestEntityTooLarge Error
 var ErrRequestEntityTooLarge = ErrorWithStatusCode{statusCode: http.StatusRequestEntityTooLarge}
 
@@ -849,6 +2122,23 @@ func NewErrRequestEntityTooLarge(message string) error {
 	}
 }
 
+// NewErrRequestEntityTooLargef returns a new ErrRequestEntityTooLarge with a formatted error message
+func NewErrRequestEntityTooLargef(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusRequestEntityTooLarge,
+	}
+}
+
+// NewErrRequestEntityTooLargeWith returns a new ErrRequestEntityTooLarge with custom error message and key / value attributes
+func NewErrRequestEntityTooLargeWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusRequestEntityTooLarge,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrRequestEntityTooLarge returns a new ErrRequestEntityTooLarge, wrapping an existing error
 func WrapErrRequestEntityTooLarge(err error) error {
 	return &ErrorWithStatusCode{
@@ -857,6 +2147,14 @@ func WrapErrRequestEntityTooLarge(err error) error {
 	}
 }
 
+// WrapErrRequestEntityTooLargef returns a new ErrRequestEntityTooLarge, wrapping an existing error with a formatted message
+func WrapErrRequestEntityTooLargef(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusRequestEntityTooLarge,
+	}
+}
+
 // ErrRequestHeaderFieldsTooLarge is a StatusRequestHeaderFieldsTooLarge Error
 var ErrRequestHeaderFieldsTooLarge = ErrorWithStatusCode{statusCode: http.StatusRequestHeaderFieldsTooLarge}
 
@@ -868,6 +2166,23 @@ func NewErrRequestHeaderFieldsTooLarge(message string) error {
 	}
 }
 
+// NewErrRequestHeaderFieldsTooLargef returns a new ErrRequestHeaderFieldsTooLarge with a formatted error message
+func NewErrRequestHeaderFieldsTooLargef(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusRequestHeaderFieldsTooLarge,
+	}
+}
+
+// NewErrRequestHeaderFieldsTooLargeWith returns a new ErrRequestHeaderFieldsTooLarge with custom error message and key / value attributes
+func NewErrRequestHeaderFieldsTooLargeWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusRequestHeaderFieldsTooLarge,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrRequestHeaderFieldsTooLarge returns a new ErrRequestHeaderFieldsTooLarge, wrapping an existing error
 func WrapErrRequestHeaderFieldsTooLarge(err error) error {
 	return &ErrorWithStatusCode{
@@ -876,6 +2191,14 @@ func WrapErrRequestHeaderFieldsTooLarge(err error) error {
 	}
 }
 
+// WrapErrRequestHeaderFieldsTooLargef returns a new ErrRequestHeaderFieldsTooLarge, wrapping an existing error with a formatted message
+func WrapErrRequestHeaderFieldsTooLargef(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusRequestHeaderFieldsTooLarge,
+	}
+}
+
 // ErrRequestTimeout is a StatusRequestTimeout Error
 var ErrRequestTimeout = ErrorWithStatusCode{statusCode: http.StatusRequestTimeout}
 
@@ -887,6 +2210,23 @@ func NewErrRequestTimeout(message string) error {
 	}
 }
 
+// NewErrRequestTimeoutf returns a new ErrRequestTimeout with a formatted error message
+func NewErrRequestTimeoutf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusRequestTimeout,
+	}
+}
+
+// NewErrRequestTimeoutWith returns a new ErrRequestTimeout with custom error message and key / value attributes
+func NewErrRequestTimeoutWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusRequestTimeout,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrRequestTimeout returns a new ErrRequestTimeout, wrapping an existing error
 func WrapErrRequestTimeout(err error) error {
 	return &ErrorWithStatusCode{
@@ -895,6 +2235,14 @@ func WrapErrRequestTimeout(err error) error {
 	}
 }
 
+// WrapErrRequestTimeoutf returns a new ErrRequestTimeout, wrapping an existing error with a formatted message
+func WrapErrRequestTimeoutf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusRequestTimeout,
+	}
+}
+
 // ErrRequestURITooLong is a StatusRequestURITooLong Error
 var ErrRequestURITooLong = ErrorWithStatusCode{statusCode: http.StatusRequestURITooLong}
 
@@ -906,6 +2254,23 @@ func NewErrRequestURITooLong(message string) error {
 	}
 }
 
+// NewErrRequestURITooLongf returns a new ErrRequestURITooLong with a formatted error message
+func NewErrRequestURITooLongf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusRequestURITooLong,
+	}
+}
+
+// NewErrRequestURITooLongWith returns a new ErrRequestURITooLong with custom error message and key / value attributes
+func NewErrRequestURITooLongWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusRequestURITooLong,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrRequestURITooLong returns a new ErrRequestURITooLong, wrapping an existing error
 func WrapErrRequestURITooLong(err error) error {
 	return &ErrorWithStatusCode{
@@ -914,6 +2279,14 @@ func WrapErrRequestURITooLong(err error) error {
 	}
 }
 
+// WrapErrRequestURITooLongf returns a new ErrRequestURITooLong, wrapping an existing error with a formatted message
+func WrapErrRequestURITooLongf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusRequestURITooLong,
+	}
+}
+
 // ErrRequestedRangeNotSatisfiable is a StatusRequestedRangeNotSatisfiable Error
 var ErrRequestedRangeNotSatisfiable = ErrorWithStatusCode{statusCode: http.StatusRequestedRangeNotSatisfiable}
 
@@ -925,6 +2298,23 @@ func NewErrRequestedRangeNotSatisfiable(message string) error {
 	}
 }
 
+// NewErrRequestedRangeNotSatisfiablef returns a new ErrRequestedRangeNotSatisfiable with a formatted error message
+func NewErrRequestedRangeNotSatisfiablef(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusRequestedRangeNotSatisfiable,
+	}
+}
+
+// NewErrRequestedRangeNotSatisfiableWith returns a new ErrRequestedRangeNotSatisfiable with custom error message and key / value attributes
+func NewErrRequestedRangeNotSatisfiableWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusRequestedRangeNotSatisfiable,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrRequestedRangeNotSatisfiable returns a new ErrRequestedRangeNotSatisfiable, wrapping an existing error
 func WrapErrRequestedRangeNotSatisfiable(err error) error {
 	return &ErrorWithStatusCode{
@@ -933,6 +2323,14 @@ func WrapErrRequestedRangeNotSatisfiable(err error) error {
 	}
 }
 
+// WrapErrRequestedRangeNotSatisfiablef returns a new ErrRequestedRangeNotSatisfiable, wrapping an existing error with a formatted message
+func WrapErrRequestedRangeNotSatisfiablef(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusRequestedRangeNotSatisfiable,
+	}
+}
+
 // ErrResetContent is a StatusResetContent Error
 var ErrResetContent = ErrorWithStatusCode{statusCode: http.StatusResetContent}
 
@@ -944,6 +2342,23 @@ func NewErrResetContent(message string) error {
 	}
 }
 
+// NewErrResetContentf returns a new ErrResetContent with a formatted error message
+func NewErrResetContentf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusResetContent,
+	}
+}
+
+// NewErrResetContentWith returns a new ErrResetContent with custom error message and key / value attributes
+func NewErrResetContentWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusResetContent,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrResetContent returns a new ErrResetContent, wrapping an existing error
 func WrapErrResetContent(err error) error {
 	return &ErrorWithStatusCode{
@@ -952,6 +2367,14 @@ func WrapErrResetContent(err error) error {
 	}
 }
 
+// WrapErrResetContentf returns a new ErrResetContent, wrapping an existing error with a formatted message
+func WrapErrResetContentf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusResetContent,
+	}
+}
+
 // ErrSeeOther is a StatusSeeOther Error
 var ErrSeeOther = ErrorWithStatusCode{statusCode: http.StatusSeeOther}
 
@@ -963,6 +2386,23 @@ func NewErrSeeOther(message string) error {
 	}
 }
 
+// NewErrSeeOtherf returns a new ErrSeeOther with a formatted error message
+func NewErrSeeOtherf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusSeeOther,
+	}
+}
+
+// NewErrSeeOtherWith returns a new ErrSeeOther with custom error message and key / value attributes
+func NewErrSeeOtherWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusSeeOther,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrSeeOther returns a new ErrSeeOther, wrapping an existing error
 func WrapErrSeeOther(err error) error {
 	return &ErrorWithStatusCode{
@@ -971,6 +2411,14 @@ func WrapErrSeeOther(err error) error {
 	}
 }
 
+// WrapErrSeeOtherf returns a new ErrSeeOther, wrapping an existing error with a formatted message
+func WrapErrSeeOtherf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusSeeOther,
+	}
+}
+
 // ErrServiceUnavailable is a StatusServiceUnavailable Error
 var ErrServiceUnavailable = ErrorWithStatusCode{statusCode: http.StatusServiceUnavailable}
 
@@ -982,6 +2430,23 @@ func NewErrServiceUnavailable(message string) error {
 	}
 }
 
+// NewErrServiceUnavailablef returns a new ErrServiceUnavailable with a formatted error message
+func NewErrServiceUnavailablef(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusServiceUnavailable,
+	}
+}
+
+// NewErrServiceUnavailableWith returns a new ErrServiceUnavailable with custom error message and key / value attributes
+func NewErrServiceUnavailableWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusServiceUnavailable,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrServiceUnavailable returns a new ErrServiceUnavailable, wrapping an existing error
 func WrapErrServiceUnavailable(err error) error {
 	return &ErrorWithStatusCode{
@@ -990,6 +2455,14 @@ func WrapErrServiceUnavailable(err error) error {
 	}
 }
 
+// WrapErrServiceUnavailablef returns a new ErrServiceUnavailable, wrapping an existing error with a formatted message
+func WrapErrServiceUnavailablef(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusServiceUnavailable,
+	}
+}
+
 // ErrSwitchingProtocols is a StatusSwitchingProtocols Error
 var ErrSwitchingProtocols = ErrorWithStatusCode{statusCode: http.StatusSwitchingProtocols}
 
@@ -1001,6 +2474,23 @@ func NewErrSwitchingProtocols(message string) error {
 	}
 }
 
+// NewErrSwitchingProtocolsf returns a new ErrSwitchingProtocols with a formatted error message
+func NewErrSwitchingProtocolsf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusSwitchingProtocols,
+	}
+}
+
+// NewErrSwitchingProtocolsWith returns a new ErrSwitchingProtocols with custom error message and key / value attributes
+func NewErrSwitchingProtocolsWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusSwitchingProtocols,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrSwitchingProtocols returns a new ErrSwitchingProtocols, wrapping an existing error
 func WrapErrSwitchingProtocols(err error) error {
 	return &ErrorWithStatusCode{
@@ -1009,6 +2499,14 @@ func WrapErrSwitchingProtocols(err error) error {
 	}
 }
 
+// WrapErrSwitchingProtocolsf returns a new ErrSwitchingProtocols, wrapping an existing error with a formatted message
+func WrapErrSwitchingProtocolsf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusSwitchingProtocols,
+	}
+}
+
 // ErrTeapot is a StatusTeapot Error
 var ErrTeapot = ErrorWithStatusCode{statusCode: http.StatusTeapot}
 
@@ -1020,6 +2518,23 @@ func NewErrTeapot(message string) error {
 	}
 }
 
+// NewErrTeapotf returns a new ErrTeapot with a formatted error message
+func NewErrTeapotf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusTeapot,
+	}
+}
+
+// NewErrTeapotWith returns a new ErrTeapot with custom error message and key / value attributes
+func NewErrTeapotWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusTeapot,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrTeapot returns a new ErrTeapot, wrapping an existing error
 func WrapErrTeapot(err error) error {
 	return &ErrorWithStatusCode{
@@ -1028,6 +2543,14 @@ func WrapErrTeapot(err error) error {
 	}
 }
 
+// WrapErrTeapotf returns a new ErrTeapot, wrapping an existing error with a formatted message
+func WrapErrTeapotf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusTeapot,
+	}
+}
+
 // ErrTemporaryRedirect is a StatusTemporaryRedirect Error
 var ErrTemporaryRedirect = ErrorWithStatusCode{statusCode: http.StatusTemporaryRedirect}
 
@@ -1039,6 +2562,23 @@ func NewErrTemporaryRedirect(message string) error {
 	}
 }
 
+// NewErrTemporaryRedirectf returns a new ErrTemporaryRedirect with a formatted error message
+func NewErrTemporaryRedirectf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusTemporaryRedirect,
+	}
+}
+
+// NewErrTemporaryRedirectWith returns a new ErrTemporaryRedirect with custom error message and key / value attributes
+func NewErrTemporaryRedirectWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusTemporaryRedirect,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrTemporaryRedirect returns a new ErrTemporaryRedirect, wrapping an existing error
 func WrapErrTemporaryRedirect(err error) error {
 	return &ErrorWithStatusCode{
@@ -1047,6 +2587,14 @@ func WrapErrTemporaryRedirect(err error) error {
 	}
 }
 
+// WrapErrTemporaryRedirectf returns a new ErrTemporaryRedirect, wrapping an existing error with a formatted message
+func WrapErrTemporaryRedirectf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusTemporaryRedirect,
+	}
+}
+
 // ErrTooEarly is a StatusTooEarly Error
 var ErrTooEarly = ErrorWithStatusCode{statusCode: http.StatusTooEarly}
 
@@ -1058,6 +2606,23 @@ func NewErrTooEarly(message string) error {
 	}
 }
 
+// NewErrTooEarlyf returns a new ErrTooEarly with a formatted error message
+func NewErrTooEarlyf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusTooEarly,
+	}
+}
+
+// NewErrTooEarlyWith returns a new ErrTooEarly with custom error message and key / value attributes
+func NewErrTooEarlyWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusTooEarly,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrTooEarly returns a new ErrTooEarly, wrapping an existing error
 func WrapErrTooEarly(err error) error {
 	return &ErrorWithStatusCode{
@@ -1066,6 +2631,14 @@ func WrapErrTooEarly(err error) error {
 	}
 }
 
+// WrapErrTooEarlyf returns a new ErrTooEarly, wrapping an existing error with a formatted message
+func WrapErrTooEarlyf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusTooEarly,
+	}
+}
+
 // ErrTooManyRequests is a StatusTooManyRequests Error
 var ErrTooManyRequests = ErrorWithStatusCode{statusCode: http.StatusTooManyRequests}
 
@@ -1077,6 +2650,23 @@ func NewErrTooManyRequests(message string) error {
 	}
 }
 
+// NewErrTooManyRequestsf returns a new ErrTooManyRequests with a formatted error message
+func NewErrTooManyRequestsf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusTooManyRequests,
+	}
+}
+
+// NewErrTooManyRequestsWith returns a new ErrTooManyRequests with custom error message and key / value attributes
+func NewErrTooManyRequestsWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusTooManyRequests,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrTooManyRequests returns a new ErrTooManyRequests, wrapping an existing error
 func WrapErrTooManyRequests(err error) error {
 	return &ErrorWithStatusCode{
@@ -1085,6 +2675,14 @@ func WrapErrTooManyRequests(err error) error {
 	}
 }
 
+// WrapErrTooManyRequestsf returns a new ErrTooManyRequests, wrapping an existing error with a formatted message
+func WrapErrTooManyRequestsf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusTooManyRequests,
+	}
+}
+
 // ErrUnauthorized is a StatusUnauthorized Error
 var ErrUnauthorized = ErrorWithStatusCode{statusCode: http.StatusUnauthorized}
 
@@ -1096,6 +2694,23 @@ func NewErrUnauthorized(message string) error {
 	}
 }
 
+// NewErrUnauthorizedf returns a new ErrUnauthorized with a formatted error message
+func NewErrUnauthorizedf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusUnauthorized,
+	}
+}
+
+// NewErrUnauthorizedWith returns a new ErrUnauthorized with custom error message and key / value attributes
+func NewErrUnauthorizedWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusUnauthorized,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrUnauthorized returns a new ErrUnauthorized, wrapping an existing error
 func WrapErrUnauthorized(err error) error {
 	return &ErrorWithStatusCode{
@@ -1104,6 +2719,14 @@ func WrapErrUnauthorized(err error) error {
 	}
 }
 
+// WrapErrUnauthorizedf returns a new ErrUnauthorized, wrapping an existing error with a formatted message
+func WrapErrUnauthorizedf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusUnauthorized,
+	}
+}
+
 // ErrUnavailableForLegalReasons is a StatusUnavailableForLegalReasons Error
 var ErrUnavailableForLegalReasons = ErrorWithStatusCode{statusCode: http.StatusUnavailableForLegalReasons}
 
@@ -1115,6 +2738,23 @@ func NewErrUnavailableForLegalReasons(message string) error {
 	}
 }
 
+// NewErrUnavailableForLegalReasonsf returns a new ErrUnavailableForLegalReasons with a formatted error message
+func NewErrUnavailableForLegalReasonsf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusUnavailableForLegalReasons,
+	}
+}
+
+// NewErrUnavailableForLegalReasonsWith returns a new ErrUnavailableForLegalReasons with custom error message and key / value attributes
+func NewErrUnavailableForLegalReasonsWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusUnavailableForLegalReasons,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrUnavailableForLegalReasons returns a new ErrUnavailableForLegalReasons, wrapping an existing error
 func WrapErrUnavailableForLegalReasons(err error) error {
 	return &ErrorWithStatusCode{
@@ -1123,6 +2763,14 @@ func WrapErrUnavailableForLegalReasons(err error) error {
 	}
 }
 
+// WrapErrUnavailableForLegalReasonsf returns a new ErrUnavailableForLegalReasons, wrapping an existing error with a formatted message
+func WrapErrUnavailableForLegalReasonsf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusUnavailableForLegalReasons,
+	}
+}
+
 // ErrUnprocessableEntity is a StatusUnprocessableEntity Error
 var ErrUnprocessableEntity = ErrorWithStatusCode{statusCode: http.StatusUnprocessableEntity}
 
@@ -1134,6 +2782,23 @@ func NewErrUnprocessableEntity(message string) error {
 	}
 }
 
+// NewErrUnprocessableEntityf returns a new ErrUnprocessableEntity with a formatted error message
+func NewErrUnprocessableEntityf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusUnprocessableEntity,
+	}
+}
+
+// NewErrUnprocessableEntityWith returns a new ErrUnprocessableEntity with custom error message and key / value attributes
+func NewErrUnprocessableEntityWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusUnprocessableEntity,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrUnprocessableEntity returns a new ErrUnprocessableEntity, wrapping an existing error
 func WrapErrUnprocessableEntity(err error) error {
 	return &ErrorWithStatusCode{
@@ -1142,6 +2807,14 @@ func WrapErrUnprocessableEntity(err error) error {
 	}
 }
 
+// WrapErrUnprocessableEntityf returns a new ErrUnprocessableEntity, wrapping an existing error with a formatted message
+func WrapErrUnprocessableEntityf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusUnprocessableEntity,
+	}
+}
+
 // ErrUnsupportedMediaType is a StatusUnsupportedMediaType Error
 var ErrUnsupportedMediaType = ErrorWithStatusCode{statusCode: http.StatusUnsupportedMediaType}
 
@@ -1153,6 +2826,23 @@ func NewErrUnsupportedMediaType(message string) error {
 	}
 }
 
+// NewErrUnsupportedMediaTypef returns a new ErrUnsupportedMediaType with a formatted error message
+func NewErrUnsupportedMediaTypef(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusUnsupportedMediaType,
+	}
+}
+
+// NewErrUnsupportedMediaTypeWith returns a new ErrUnsupportedMediaType with custom error message and key / value attributes
+func NewErrUnsupportedMediaTypeWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusUnsupportedMediaType,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrUnsupportedMediaType returns a new ErrUnsupportedMediaType, wrapping an existing error
 func WrapErrUnsupportedMediaType(err error) error {
 	return &ErrorWithStatusCode{
@@ -1161,6 +2851,14 @@ func WrapErrUnsupportedMediaType(err error) error {
 	}
 }
 
+// WrapErrUnsupportedMediaTypef returns a new ErrUnsupportedMediaType, wrapping an existing error with a formatted message
+func WrapErrUnsupportedMediaTypef(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusUnsupportedMediaType,
+	}
+}
+
 // ErrUpgradeRequired is a StatusUpgradeRequired Error
 var ErrUpgradeRequired = ErrorWithStatusCode{statusCode: http.StatusUpgradeRequired}
 
@@ -1172,6 +2870,23 @@ func NewErrUpgradeRequired(message string) error {
 	}
 }
 
+// NewErrUpgradeRequiredf returns a new ErrUpgradeRequired with a formatted error message
+func NewErrUpgradeRequiredf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusUpgradeRequired,
+	}
+}
+
+// NewErrUpgradeRequiredWith returns a new ErrUpgradeRequired with custom error message and key / value attributes
+func NewErrUpgradeRequiredWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusUpgradeRequired,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrUpgradeRequired returns a new ErrUpgradeRequired, wrapping an existing error
 func WrapErrUpgradeRequired(err error) error {
 	return &ErrorWithStatusCode{
@@ -1180,6 +2895,14 @@ func WrapErrUpgradeRequired(err error) error {
 	}
 }
 
+// WrapErrUpgradeRequiredf returns a new ErrUpgradeRequired, wrapping an existing error with a formatted message
+func WrapErrUpgradeRequiredf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusUpgradeRequired,
+	}
+}
+
 // ErrUseProxy is a StatusUseProxy Error
 var ErrUseProxy = ErrorWithStatusCode{statusCode: http.StatusUseProxy}
 
@@ -1191,6 +2914,23 @@ func NewErrUseProxy(message string) error {
 	}
 }
 
+// NewErrUseProxyf returns a new ErrUseProxy with a formatted error message
+func NewErrUseProxyf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusUseProxy,
+	}
+}
+
+// NewErrUseProxyWith returns a new ErrUseProxy with custom error message and key / value attributes
+func NewErrUseProxyWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusUseProxy,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrUseProxy returns a new ErrUseProxy, wrapping an existing error
 func WrapErrUseProxy(err error) error {
 	return &ErrorWithStatusCode{
@@ -1199,6 +2939,14 @@ func WrapErrUseProxy(err error) error {
 	}
 }
 
+// WrapErrUseProxyf returns a new ErrUseProxy, wrapping an existing error with a formatted message
+func WrapErrUseProxyf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusUseProxy,
+	}
+}
+
 // ErrVariantAlsoNegotiates is a StatusVariantAlsoNegotiates Error
 var ErrVariantAlsoNegotiates = ErrorWithStatusCode{statusCode: http.StatusVariantAlsoNegotiates}
 
@@ -1210,6 +2958,23 @@ func NewErrVariantAlsoNegotiates(message string) error {
 	}
 }
 
+// NewErrVariantAlsoNegotiatesf returns a new ErrVariantAlsoNegotiates with a formatted error message
+func NewErrVariantAlsoNegotiatesf(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf(format, args...),
+		statusCode: http.StatusVariantAlsoNegotiates,
+	}
+}
+
+// NewErrVariantAlsoNegotiatesWith returns a new ErrVariantAlsoNegotiates with custom error message and key / value attributes
+func NewErrVariantAlsoNegotiatesWith(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: http.StatusVariantAlsoNegotiates,
+		details:    &errorDetails{attributes: attributes},
+	}
+}
+
 // WrapErrVariantAlsoNegotiates returns a new ErrVariantAlsoNegotiates, wrapping an existing error
 func WrapErrVariantAlsoNegotiates(err error) error {
 	return &ErrorWithStatusCode{
@@ -1218,6 +2983,59 @@ func WrapErrVariantAlsoNegotiates(err error) error {
 	}
 }
 
+// WrapErrVariantAlsoNegotiatesf returns a new ErrVariantAlsoNegotiates, wrapping an existing error with a formatted message
+func WrapErrVariantAlsoNegotiatesf(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error:      fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: http.StatusVariantAlsoNegotiates,
+	}
+}
+
+// ErrorAttributes returns the key / value attributes of all errors in the chain of err, outermost
+// first. They can be passed as is to the structured logger functions
+func ErrorAttributes(err error) []interface{} {
+	var attributes []interface{}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		if withAttributes, ok := err.(WithAttributes); ok {
+			attributes = append(attributes, withAttributes.Attributes()...)
+		}
+	}
+
+	return attributes
+}
+
+// StatusCodeOf returns the status code of the first error with status code in the chain of err. A
+// nil error has status code 200, and an error without one has status code 500
+func StatusCodeOf(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	var withStatusCode WithStatusCode
+	if errors.As(err, &withStatusCode) {
+		return withStatusCode.StatusCode()
+	}
+
+	return http.StatusInternalServerError
+}
+
+// NewErrorWithStatusCode returns a new error with any status code and a custom error message
+func NewErrorWithStatusCode(statusCode int, message string) error {
+	return &ErrorWithStatusCode{
+		error:      errors.New(message),
+		statusCode: statusCode,
+	}
+}
+
+// WrapErrorWithStatusCode returns a new error with any status code, wrapping an existing error
+func WrapErrorWithStatusCode(statusCode int, err error) error {
+	return &ErrorWithStatusCode{
+		error:      err,
+		statusCode: statusCode,
+	}
+}
+
 func GetByStatusCode(statusCode int) func(string) error {
 	switch statusCode {
 
@@ -1233,6 +3051,9 @@ func GetByStatusCode(statusCode int) func(string) error {
 	case http.StatusBadRequest:
 		return NewErrBadRequest
 
+	case StatusClientClosedRequest:
+		return NewErrClientClosedRequest
+
 	case http.StatusConflict:
 		return NewErrConflict
 
@@ -1302,6 +3123,12 @@ func GetByStatusCode(statusCode int) func(string) error {
 	case http.StatusNetworkAuthenticationRequired:
 		return NewErrNetworkAuthenticationRequired
 
+	case StatusNetworkConnectTimeout:
+		return NewErrNetworkConnectTimeout
+
+	case StatusNetworkReadTimeout:
+		return NewErrNetworkReadTimeout
+
 	case http.StatusNoContent:
 		return NewErrNoContent
 
@@ -1423,6 +3250,9 @@ func GetWrapByStatusCode(statusCode int) func(error) error {
 	case http.StatusBadRequest:
 		return WrapErrBadRequest
 
+	case StatusClientClosedRequest:
+		return WrapErrClientClosedRequest
+
 	case http.StatusConflict:
 		return WrapErrConflict
 
@@ -1492,6 +3322,12 @@ func GetWrapByStatusCode(statusCode int) func(error) error {
 	case http.StatusNetworkAuthenticationRequired:
 		return WrapErrNetworkAuthenticationRequired
 
+	case StatusNetworkConnectTimeout:
+		return WrapErrNetworkConnectTimeout
+
+	case StatusNetworkReadTimeout:
+		return WrapErrNetworkReadTimeout
+
 	case http.StatusNoContent:
 		return WrapErrNoContent
 
@@ -1603,6 +3439,7 @@ var defaultMessages = map[int]string{
 	http.StatusAlreadyReported:               "Already Reported",
 	http.StatusBadGateway:                    "Bad Gateway",
 	http.StatusBadRequest:                    "Bad Request",
+	StatusClientClosedRequest:                "Client Closed Request",
 	http.StatusConflict:                      "Conflict",
 	http.StatusContinue:                      "Continue",
 	http.StatusCreated:                       "Created",
@@ -1626,6 +3463,8 @@ var defaultMessages = map[int]string{
 	http.StatusMultiStatus:                   "Multi Status",
 	http.StatusMultipleChoices:               "Multiple Choices",
 	http.StatusNetworkAuthenticationRequired: "Network Authentication Required",
+	StatusNetworkConnectTimeout:              "Network Connect Timeout Error",
+	StatusNetworkReadTimeout:                 "Network Read Timeout Error",
 	http.StatusNoContent:                     "No Content",
 	http.StatusNonAuthoritativeInfo:          "Non Authoritative Info",
 	http.StatusNotAcceptable:                 "Not Acceptable",
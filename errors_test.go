@@ -69,6 +69,108 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestIs(t *testing.T) {
+	err := fmt.Errorf("loading user: %w", NewErrNotFound("no such user"))
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected wrapped error to match ErrNotFound")
+	}
+
+	if errors.Is(err, ErrConflict) {
+		t.Fatalf("Expected wrapped error not to match ErrConflict")
+	}
+
+	var withStatusCode WithStatusCode = ErrNotFound
+	if withStatusCode.StatusCode() != http.StatusNotFound {
+		t.Fatalf("Sentinel should be a WithStatusCode")
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := WrapErrBadGateway(cause)
+
+	if !errors.Is(err, cause) {
+		t.Fatalf("Expected wrapped cause to be found")
+	}
+
+	var errorWithStatusCode *ErrorWithStatusCode
+	if !errors.As(fmt.Errorf("calling: %w", err), &errorWithStatusCode) {
+		t.Fatalf("Expected errors.As to find the error with status code")
+	}
+}
+
+func TestStatusCodeOf(t *testing.T) {
+	for _, testCase := range []struct {
+		err                error
+		expectedStatusCode int
+	}{
+		{err: nil, expectedStatusCode: http.StatusOK},
+		{err: errors.New("boom"), expectedStatusCode: http.StatusInternalServerError},
+		{err: ErrTooManyRequests, expectedStatusCode: http.StatusTooManyRequests},
+		{err: fmt.Errorf("context: %w", NewErrForbidden("no")), expectedStatusCode: http.StatusForbidden},
+	} {
+		if statusCode := StatusCodeOf(testCase.err); statusCode != testCase.expectedStatusCode {
+			t.Fatalf("Bad status code for %v: %d != %d", testCase.err, statusCode, testCase.expectedStatusCode)
+		}
+	}
+}
+
+func TestFormattedConstructors(t *testing.T) {
+	err := NewErrNotFoundf("user %s not found", "jdoe")
+	if err.Error() != "user jdoe not found" || StatusCodeOf(err) != http.StatusNotFound {
+		t.Fatalf("Bad formatted error: %s", err)
+	}
+
+	cause := errors.New("connection refused")
+	err = WrapErrBadGatewayf(cause, "calling %s", "inventory")
+	if err.Error() != "calling inventory: connection refused" {
+		t.Fatalf("Bad wrapped message: %s", err)
+	}
+
+	if !errors.Is(err, cause) || !errors.Is(err, ErrBadGateway) {
+		t.Fatalf("Wrapped error should match both cause and sentinel")
+	}
+}
+
+func TestErrorAttributes(t *testing.T) {
+	err := fmt.Errorf("handling order: %w", NewErrConflictWith("order exists", "orderID", "o1", "attempt", 2))
+
+	attributes := ErrorAttributes(err)
+	if len(attributes) != 4 || attributes[0] != "orderID" || attributes[3] != 2 {
+		t.Fatalf("Bad attributes: %v", attributes)
+	}
+
+	if ErrorAttributes(NewErrConflict("no attributes")) != nil {
+		t.Fatalf("Expected no attributes")
+	}
+
+	// sentinels must remain comparable
+	var sentinel error = ErrConflict
+	if sentinel != ErrConflict {
+		t.Fatalf("Sentinel should be equal to itself")
+	}
+}
+
+func TestExtraStatusCodes(t *testing.T) {
+	err := GetByStatusCode(StatusClientClosedRequest)("client went away")
+	if err == nil || StatusCodeOf(err) != 499 {
+		t.Fatalf("Bad extra status error: %v", err)
+	}
+
+	if !errors.Is(err, ErrClientClosedRequest) {
+		t.Fatalf("Expected extra status error to match its sentinel")
+	}
+
+	if ErrNetworkConnectTimeout.Error() != "Network Connect Timeout Error" {
+		t.Fatalf("Bad default message: %s", ErrNetworkConnectTimeout.Error())
+	}
+
+	if StatusCodeOf(NewErrorWithStatusCode(299, "custom")) != 299 {
+		t.Fatalf("Bad custom status code")
+	}
+}
+
 func ExampleErrNotFound() {
 	fmt.Print(ErrNotFound.Error())
 
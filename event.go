@@ -18,7 +18,6 @@ package nuclio
 
 import (
 	"errors"
-	"strconv"
 	"time"
 )
 
@@ -192,22 +191,7 @@ func (ae *AbstractEvent) GetHeaderString(key string) string {
 
 // GetHeaderInt returns the field by name as an integer
 func (ae *AbstractEvent) GetHeaderInt(key string) (int, error) {
-
-	// try to get header as an interface
-	headerAsInterface := ae.GetHeader(key)
-
-	// if the header value is not an integer
-	switch typedHeader := headerAsInterface.(type) {
-	case int:
-		return typedHeader, nil
-	case string:
-		return strconv.Atoi(typedHeader)
-	case []byte:
-		return strconv.Atoi(string(typedHeader))
-
-	default:
-		return 0, ErrTypeConversion
-	}
+	return convertToIntAccessorValue(ae.GetHeader(key))
 }
 
 // GetHeaders loads all headers into a map of string / interface{}
@@ -0,0 +1,177 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTypedHeaderAccessors(t *testing.T) {
+	event := &MemoryEvent{
+		Headers: map[string]interface{}{
+			"X-Count":      "42",
+			"X-Big":        []byte("9007199254740993"),
+			"X-Ratio":      "0.25",
+			"X-Enabled":    "true",
+			"X-Timeout":    "1m30s",
+			"Retry-After":  "120",
+			"X-Since":      "2023-01-02T03:04:05Z",
+			"Date":         "Mon, 02 Jan 2023 03:04:05 GMT",
+			"Accept":       []string{"text/plain", "application/json"},
+			"X-Not-Number": "abc",
+		},
+	}
+
+	if count, err := event.GetHeaderInt("X-Count"); err != nil || count != 42 {
+		t.Fatalf("Bad int header: %d, %v", count, err)
+	}
+
+	if big, err := HeaderInt64(event, "X-Big"); err != nil || big != 9007199254740993 {
+		t.Fatalf("Bad int64 header: %d, %v", big, err)
+	}
+
+	if ratio, err := HeaderFloat(event, "X-Ratio"); err != nil || ratio != 0.25 {
+		t.Fatalf("Bad float header: %f, %v", ratio, err)
+	}
+
+	if enabled, err := HeaderBool(event, "X-Enabled"); err != nil || !enabled {
+		t.Fatalf("Bad bool header: %t, %v", enabled, err)
+	}
+
+	for headerName, expectedDuration := range map[string]time.Duration{
+		"X-Timeout":   90 * time.Second,
+		"Retry-After": 2 * time.Minute,
+	} {
+		if duration, err := HeaderDuration(event, headerName); err != nil || duration != expectedDuration {
+			t.Fatalf("Bad duration header %s: %s, %v", headerName, duration, err)
+		}
+	}
+
+	expectedTime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, headerName := range []string{"X-Since", "Date"} {
+		if headerTime, err := HeaderTime(event, headerName); err != nil || !headerTime.Equal(expectedTime) {
+			t.Fatalf("Bad time header %s: %s, %v", headerName, headerTime, err)
+		}
+	}
+
+	if values := HeaderValues(event, "Accept"); len(values) != 2 || values[1] != "application/json" {
+		t.Fatalf("Bad header values: %v", values)
+	}
+
+	if values := HeaderValues(event, "X-Count"); len(values) != 1 || values[0] != "42" {
+		t.Fatalf("Bad single header values: %v", values)
+	}
+
+	if values := HeaderValues(event, "X-Missing"); len(values) != 0 {
+		t.Fatalf("Expected no values for missing header: %v", values)
+	}
+
+	if event.GetHeaderString("Accept") != "text/plain" {
+		t.Fatalf("Bad string of repeated header: %s", event.GetHeaderString("Accept"))
+	}
+
+	for _, headerName := range []string{"X-Not-Number", "X-Missing"} {
+		if _, err := event.GetHeaderInt(headerName); !errors.Is(err, ErrTypeConversion) {
+			t.Fatalf("Expected type conversion error for %s, got %v", headerName, err)
+		}
+	}
+	// missing values fail with the sentinel itself
+	if _, err := event.GetHeaderInt("X-Missing"); err != ErrTypeConversion {
+		t.Fatalf("Expected bare type conversion error, got %v", err)
+	}
+
+	if _, err := (&AbstractEvent{}).GetHeaderInt("X-Count"); err != ErrTypeConversion {
+		t.Fatalf("Expected bare type conversion error, got %v", err)
+	}
+
+	if _, err := event.GetFieldInt("missing"); err != ErrTypeConversion {
+		t.Fatalf("Expected bare type conversion error, got %v", err)
+	}
+}
+
+func TestTypedFieldAccessors(t *testing.T) {
+	event := &MemoryEvent{
+		Fields: map[string]interface{}{
+			"count":   int64(7),
+			"ratio":   float32(0.5),
+			"enabled": true,
+			"timeout": 30 * time.Second,
+			"since":   int64(1672628645),
+			"name":    []byte("orders"),
+		},
+	}
+
+	if count, err := event.GetFieldInt("count"); err != nil || count != 7 {
+		t.Fatalf("Bad int field: %d, %v", count, err)
+	}
+
+	if ratio, err := FieldFloat(event, "ratio"); err != nil || ratio != 0.5 {
+		t.Fatalf("Bad float field: %f, %v", ratio, err)
+	}
+
+	if enabled, err := FieldBool(event, "enabled"); err != nil || !enabled {
+		t.Fatalf("Bad bool field: %t, %v", enabled, err)
+	}
+
+	if timeout, err := FieldDuration(event, "timeout"); err != nil || timeout != 30*time.Second {
+		t.Fatalf("Bad duration field: %s, %v", timeout, err)
+	}
+
+	if since, err := FieldTime(event, "since"); err != nil || since.Unix() != 1672628645 {
+		t.Fatalf("Bad time field: %s, %v", since, err)
+	}
+
+	if event.GetFieldString("name") != "orders" {
+		t.Fatalf("Bad string field: %s", event.GetFieldString("name"))
+	}
+
+	if _, err := FieldInt64(event, "enabled"); !errors.Is(err, ErrTypeConversion) {
+		t.Fatalf("Expected type conversion error, got %v", err)
+	}
+}
+
+type customHeaderEvent struct {
+	AbstractEvent
+	headers map[string]interface{}
+}
+
+func (che *customHeaderEvent) GetHeader(key string) interface{} {
+	return che.headers[key]
+}
+
+func (che *customHeaderEvent) GetField(key string) interface{} {
+	return che.headers[key]
+}
+
+func TestTypedAccessorsOfCustomEvent(t *testing.T) {
+	event := &customHeaderEvent{headers: map[string]interface{}{"X-Count": "42", "X-Timeout": 5}}
+
+	// the accessors see the headers and fields of events which only override GetHeader and GetField
+	if count, err := HeaderInt64(event, "X-Count"); err != nil || count != 42 {
+		t.Fatalf("Bad int64 header: %d, %v", count, err)
+	}
+
+	if timeout, err := FieldDuration(event, "X-Timeout"); err != nil || timeout != 5*time.Second {
+		t.Fatalf("Bad duration field: %s, %v", timeout, err)
+	}
+
+	if _, err := HeaderBool(event, "X-Missing"); !errors.Is(err, ErrTypeConversion) {
+		t.Fatalf("Expected type conversion error, got %v", err)
+	}
+}
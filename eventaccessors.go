@@ -0,0 +1,82 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import "time"
+
+// HeaderInt64 returns a header of the event as a 64 bit integer. Values which can't be converted
+// fail with ErrTypeConversion
+func HeaderInt64(event Event, key string) (int64, error) {
+	return convertToInt64(event.GetHeader(key))
+}
+
+// HeaderFloat returns a header of the event as a float. Values which can't be converted fail with
+// ErrTypeConversion
+func HeaderFloat(event Event, key string) (float64, error) {
+	return convertToFloat(event.GetHeader(key))
+}
+
+// HeaderBool returns a header of the event as a boolean (e.g. "true", "1", "false"). Values which
+// can't be converted fail with ErrTypeConversion
+func HeaderBool(event Event, key string) (bool, error) {
+	return convertToBool(event.GetHeader(key))
+}
+
+// HeaderDuration returns a header of the event as a duration. Durations are given as strings like
+// "1m30s", or as integers which are taken as seconds
+func HeaderDuration(event Event, key string) (time.Duration, error) {
+	return convertToDuration(event.GetHeader(key))
+}
+
+// HeaderTime returns a header of the event as a time. Times are given as RFC 3339 or HTTP dates, or
+// as integers which are taken as seconds since the epoch
+func HeaderTime(event Event, key string) (time.Time, error) {
+	return convertToTime(event.GetHeader(key))
+}
+
+// HeaderValues returns all values of a header of the event, for headers which may repeat
+func HeaderValues(event Event, key string) []string {
+	return convertToStringValues(event.GetHeader(key))
+}
+
+// FieldInt64 returns a field of the event as a 64 bit integer. Values which can't be converted fail
+// with ErrTypeConversion
+func FieldInt64(event Event, key string) (int64, error) {
+	return convertToInt64(event.GetField(key))
+}
+
+// FieldFloat returns a field of the event as a float. Values which can't be converted fail with
+// ErrTypeConversion
+func FieldFloat(event Event, key string) (float64, error) {
+	return convertToFloat(event.GetField(key))
+}
+
+// FieldBool returns a field of the event as a boolean. Values which can't be converted fail with
+// ErrTypeConversion
+func FieldBool(event Event, key string) (bool, error) {
+	return convertToBool(event.GetField(key))
+}
+
+// FieldDuration returns a field of the event as a duration, converted like HeaderDuration
+func FieldDuration(event Event, key string) (time.Duration, error) {
+	return convertToDuration(event.GetField(key))
+}
+
+// FieldTime returns a field of the event as a time, converted like HeaderTime
+func FieldTime(event Event, key string) (time.Time, error) {
+	return convertToTime(event.GetField(key))
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// EventRecordVersion is the version of the event record format written by NewEventRecord
+const EventRecordVersion = 1
+
+// EventRecord is a serializable snapshot of an event, used to capture events and replay them later
+// (e.g. feeding events captured in production to handlers in tests). Records are encoded as JSON with
+// MarshalEvent, or as msgpack with MarshalEventMsgpack
+type EventRecord struct {
+	Version        int                    `json:"version"`
+	ID             ID                     `json:"id,omitempty"`
+	Trigger        *TriggerInfo           `json:"trigger,omitempty"`
+	Method         string                 `json:"method,omitempty"`
+	Path           string                 `json:"path,omitempty"`
+	URL            string                 `json:"url,omitempty"`
+	ContentType    string                 `json:"contentType,omitempty"`
+	Headers        map[string]interface{} `json:"headers,omitempty"`
+	Fields         map[string]interface{} `json:"fields,omitempty"`
+	Body           []byte                 `json:"body,omitempty"`
+	Timestamp      time.Time              `json:"timestamp"`
+	ShardID        int                    `json:"shardId"`
+	TotalNumShards int                    `json:"totalNumShards,omitempty"`
+	Offset         int                    `json:"offset,omitempty"`
+	Topic          string                 `json:"topic,omitempty"`
+	Type           string                 `json:"type,omitempty"`
+	TypeVersion    string                 `json:"typeVersion,omitempty"`
+	EventVersion   string                 `json:"eventVersion,omitempty"`
+	LastInBatch    bool                   `json:"lastInBatch,omitempty"`
+}
+
+// NewEventRecord captures the event in a record. Header values are recorded as strings (or string
+// slices, for headers which repeat), and so are field values which are byte slices, which JSON would
+// otherwise encode as base64. JSON replaces bytes which aren't valid UTF-8, so events with binary
+// fields should be recorded with msgpack
+func NewEventRecord(event Event) *EventRecord {
+	record := &EventRecord{
+		Version:        EventRecordVersion,
+		ID:             event.GetID(),
+		Method:         event.GetMethod(),
+		Path:           event.GetPath(),
+		URL:            event.GetURL(),
+		ContentType:    getEventContentType(event),
+		Body:           event.GetBody(),
+		Timestamp:      event.GetTimestamp(),
+		ShardID:        event.GetShardID(),
+		TotalNumShards: event.GetTotalNumShards(),
+		Offset:         event.GetOffset(),
+		Topic:          event.GetTopic(),
+		Type:           event.GetType(),
+		TypeVersion:    event.GetTypeVersion(),
+		EventVersion:   event.GetVersion(),
+		LastInBatch:    event.GetLastInBatch(),
+	}
+
+	if triggerInfo := event.GetTriggerInfo(); triggerInfo != nil {
+		record.Trigger = &TriggerInfo{
+			Class: triggerInfo.GetClass(),
+			Kind:  triggerInfo.GetKind(),
+			Name:  triggerInfo.GetName(),
+		}
+	}
+
+	if fields := event.GetFields(); fields != nil {
+		record.Fields = make(map[string]interface{}, len(fields))
+
+		for fieldKey, fieldValue := range fields {
+			if byteSliceValue, isByteSlice := fieldValue.([]byte); isByteSlice {
+				fieldValue = string(byteSliceValue)
+			}
+
+			record.Fields[fieldKey] = fieldValue
+		}
+	}
+
+	if headers := event.GetHeaders(); len(headers) > 0 {
+		record.Headers = make(map[string]interface{}, len(headers))
+
+		for headerKey, headerValue := range headers {
+			headerValues := convertToStringValues(headerValue)
+
+			switch len(headerValues) {
+			case 0:
+				record.Headers[headerKey] = ""
+			case 1:
+				record.Headers[headerKey] = headerValues[0]
+			default:
+				record.Headers[headerKey] = headerValues
+			}
+		}
+	}
+
+	return record
+}
+
+// Event reconstructs the recorded event
+func (er *EventRecord) Event() *MemoryEvent {
+	event := &MemoryEvent{
+		Method:         er.Method,
+		ContentType:    er.ContentType,
+		Body:           er.Body,
+		Path:           er.Path,
+		URL:            er.URL,
+		Fields:         er.Fields,
+		Timestamp:      er.Timestamp,
+		TotalNumShards: er.TotalNumShards,
+		Offset:         er.Offset,
+		Topic:          er.Topic,
+		Type:           er.Type,
+		TypeVersion:    er.TypeVersion,
+		Version:        er.EventVersion,
+		LastInBatch:    er.LastInBatch,
+	}
+
+	if er.ShardID >= 0 {
+		shardID := er.ShardID
+		event.ShardID = &shardID
+	}
+
+	if er.Headers != nil {
+		event.Headers = make(Headers, len(er.Headers))
+		for headerKey, headerValue := range er.Headers {
+			event.Headers[headerKey] = headerValue
+		}
+	}
+
+	event.SetID(er.ID)
+
+	if er.Trigger != nil {
+		triggerInfo := *er.Trigger
+		event.SetTriggerInfoProvider(&triggerInfo)
+	}
+
+	return event
+}
+
+// MarshalEvent encodes the event as a JSON event record
+func MarshalEvent(event Event) ([]byte, error) {
+	return json.Marshal(NewEventRecord(event))
+}
+
+// UnmarshalEvent decodes a JSON event record into the recorded event. Integral numbers in fields are
+// decoded as int64 and other numbers as float64
+func UnmarshalEvent(data []byte) (*MemoryEvent, error) {
+	record := EventRecord{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&record); err != nil {
+		return nil, fmt.Errorf("Failed to decode event record: %w", err)
+	}
+
+	if err := record.validateVersion(); err != nil {
+		return nil, err
+	}
+
+	for fieldName, fieldValue := range record.Fields {
+		record.Fields[fieldName] = normalizeJSONNumbers(fieldValue)
+	}
+
+	for headerKey, headerValue := range record.Headers {
+		record.Headers[headerKey] = normalizeRecordedHeader(headerValue)
+	}
+
+	return record.Event(), nil
+}
+
+// MarshalEventMsgpack encodes the event as a msgpack event record, which is more compact than JSON
+// and keeps the types of fields
+func MarshalEventMsgpack(event Event) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	encoder := msgpack.NewEncoder(&buffer)
+	encoder.SetCustomStructTag("json")
+
+	if err := encoder.Encode(NewEventRecord(event)); err != nil {
+		return nil, fmt.Errorf("Failed to encode event record: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalEventMsgpack decodes a msgpack event record into the recorded event
+func UnmarshalEventMsgpack(data []byte) (*MemoryEvent, error) {
+	record := EventRecord{}
+
+	if err := decodeMsgpack(data, &record); err != nil {
+		return nil, fmt.Errorf("Failed to decode event record: %w", err)
+	}
+
+	if err := record.validateVersion(); err != nil {
+		return nil, err
+	}
+
+	for headerKey, headerValue := range record.Headers {
+		record.Headers[headerKey] = normalizeRecordedHeader(headerValue)
+	}
+
+	return record.Event(), nil
+}
+
+func (er *EventRecord) validateVersion() error {
+	if er.Version < 1 || er.Version > EventRecordVersion {
+		return fmt.Errorf("Unsupported event record version %d", er.Version)
+	}
+
+	return nil
+}
+
+// normalizeRecordedHeader restores header values which repeat, decoded as []interface{}, to []string
+func normalizeRecordedHeader(headerValue interface{}) interface{} {
+	if _, isSlice := headerValue.([]interface{}); isSlice {
+		return convertToStringValues(headerValue)
+	}
+
+	return headerValue
+}
+
+// normalizeJSONNumbers replaces the json.Numbers of a decoded value with int64s or float64s
+func normalizeJSONNumbers(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case json.Number:
+		if int64Value, err := typedValue.Int64(); err == nil {
+			return int64Value
+		}
+
+		if floatValue, err := typedValue.Float64(); err == nil {
+			return floatValue
+		}
+
+		return typedValue.String()
+	case map[string]interface{}:
+		for key, elementValue := range typedValue {
+			typedValue[key] = normalizeJSONNumbers(elementValue)
+		}
+	case []interface{}:
+		for index, elementValue := range typedValue {
+			typedValue[index] = normalizeJSONNumbers(elementValue)
+		}
+	}
+
+	return value
+}
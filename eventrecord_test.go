@@ -0,0 +1,150 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newRecordedEvent() *MemoryEvent {
+	event := &MemoryEvent{
+		Method:      "POST",
+		ContentType: ContentTypeJSON,
+		Body:        []byte(`{"quantity": 3}`),
+		Path:        "/orders",
+		URL:         "http://orders:8080/orders?dryRun=true",
+		Headers: map[string]interface{}{
+			"X-Request-Id": []byte("r1"),
+			"X-Count":      3,
+			"Set-Cookie":   []string{"a=1", "b=2"},
+		},
+		Fields: map[string]interface{}{
+			"partition": 7,
+			"ratio":     0.5,
+			"key":       "k1",
+			"payload":   []byte("raw"),
+		},
+		Timestamp: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		Offset:    1234,
+		Topic:     "orders",
+	}
+
+	event.WithShard(2, 0)
+	event.SetID("e1")
+	event.SetTriggerInfoProvider(&TriggerInfo{Class: "async", Kind: "kafka-cluster", Name: "orders"})
+
+	return event
+}
+
+func TestMarshalEvent(t *testing.T) {
+	data, err := MarshalEvent(newRecordedEvent())
+	if err != nil {
+		t.Fatalf("Failed to marshal event: %s", err)
+	}
+
+	if !strings.Contains(string(data), `"version":1`) {
+		t.Fatalf("Record should be versioned: %s", data)
+	}
+
+	event, err := UnmarshalEvent(data)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal event: %s", err)
+	}
+
+	verifyReplayedEvent(t, event)
+
+	// JSON has a single number type, so integers are restored as int64
+	if partition, err := event.GetFieldInt("partition"); err != nil || partition != 7 {
+		t.Fatalf("Bad int field: %d, %v", partition, err)
+	}
+
+	if event.GetField("ratio") != 0.5 {
+		t.Fatalf("Bad float field: %v", event.GetField("ratio"))
+	}
+}
+
+func TestMarshalEventMsgpack(t *testing.T) {
+	data, err := MarshalEventMsgpack(newRecordedEvent())
+	if err != nil {
+		t.Fatalf("Failed to marshal event: %s", err)
+	}
+
+	event, err := UnmarshalEventMsgpack(data)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal event: %s", err)
+	}
+
+	verifyReplayedEvent(t, event)
+
+	if partition, err := event.GetFieldInt("partition"); err != nil || partition != 7 {
+		t.Fatalf("Bad int field: %d, %v", partition, err)
+	}
+}
+
+func TestUnmarshalEventVersion(t *testing.T) {
+	if _, err := UnmarshalEvent([]byte(`{"version": 2}`)); err == nil {
+		t.Fatalf("Expected error for unsupported version")
+	}
+
+	if _, err := UnmarshalEvent([]byte(`{"id": "e1"}`)); err == nil {
+		t.Fatalf("Expected error for missing version")
+	}
+}
+
+func verifyReplayedEvent(t *testing.T, event *MemoryEvent) {
+	if event.GetID() != "e1" ||
+		event.GetMethod() != "POST" ||
+		event.GetPath() != "/orders" ||
+		event.GetURL() != "http://orders:8080/orders?dryRun=true" ||
+		event.GetContentType() != ContentTypeJSON ||
+		string(event.GetBody()) != `{"quantity": 3}` ||
+		event.GetShardID() != 2 ||
+		event.GetOffset() != 1234 ||
+		event.GetTopic() != "orders" ||
+		!event.GetTimestamp().Equal(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Fatalf("Bad replayed event: %+v", event)
+	}
+
+	triggerInfo := event.GetTriggerInfo()
+	if triggerInfo == nil || triggerInfo.GetKind() != "kafka-cluster" || triggerInfo.GetName() != "orders" {
+		t.Fatalf("Bad replayed trigger info: %+v", triggerInfo)
+	}
+
+	if event.GetHeaderString("x-request-id") != "r1" {
+		t.Fatalf("Bad replayed header: %q", event.GetHeaderString("x-request-id"))
+	}
+
+	if count, err := event.GetHeaderInt("X-Count"); err != nil || count != 3 {
+		t.Fatalf("Bad replayed int header: %d, %v", count, err)
+	}
+
+	if values := HeaderValues(event, "Set-Cookie"); !reflect.DeepEqual(values, []string{"a=1", "b=2"}) {
+		t.Fatalf("Bad replayed repeated header: %v", values)
+	}
+
+	if event.GetFieldString("key") != "k1" {
+		t.Fatalf("Bad replayed field: %v", event.GetField("key"))
+	}
+
+	// byte slice fields are recorded as strings rather than base64
+	if event.GetFieldString("payload") != "raw" || string(event.GetFieldByteSlice("payload")) != "raw" {
+		t.Fatalf("Bad replayed byte slice field: %v", event.GetField("payload"))
+	}
+}
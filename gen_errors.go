@@ -17,12 +17,15 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Generate errors.go from constants in net/http
+// Generate errors.go from constants in net/http, and optionally from a JSON file of extra status codes
 package main
 
 import (
+	"encoding/json"
 	"flag"
+	"go/constant"
 	"go/importer"
+	"go/types"
 	"log"
 	"os"
 	"regexp"
@@ -65,22 +68,131 @@ type WithStatusCode interface {
 	StatusCode() int
 }
 
+// WithAttributes is an error carrying structured key / value pairs, in the form accepted by the
+// structured logger functions (e.g. InfoWith)
+type WithAttributes interface {
+	Attributes() []interface{}
+}
+
+// WithResponse is an error specifying parts of the response sent when a handler returns it
+type WithResponse interface {
+	ResponseHeaders() map[string]interface{}
+	ResponseContentType() string
+	ResponseBody() []byte
+}
+
 // ErrorWithStatusCode implements both error and WithStatusCode
 type ErrorWithStatusCode struct {
 	error
 	statusCode int
+
+	// details is a pointer, so that errors remain comparable
+	details *errorDetails
+}
+
+// errorDetails holds the optional parts of an ErrorWithStatusCode
+type errorDetails struct {
+	attributes  []interface{}
+	headers     map[string]interface{}
+	contentType string
+	body        []byte
+}
+
+func (ed *errorDetails) clone() *errorDetails {
+	if ed == nil {
+		return &errorDetails{headers: map[string]interface{}{}}
+	}
+
+	clonedDetails := *ed
+	clonedDetails.headers = make(map[string]interface{}, len(ed.headers))
+	for headerKey, headerValue := range ed.headers {
+		clonedDetails.headers[headerKey] = headerValue
+	}
+
+	return &clonedDetails
 }
 
 // GetError returns the underlying error
-func (e *ErrorWithStatusCode) GetError() error {
+func (e ErrorWithStatusCode) GetError() error {
 	return e.error
 }
 
 // StatusCode returns the status code
-func (e *ErrorWithStatusCode) StatusCode() int {
+func (e ErrorWithStatusCode) StatusCode() int {
 	return e.statusCode
 }
 
+// Attributes returns the key / value pairs attached to the error
+func (e ErrorWithStatusCode) Attributes() []interface{} {
+	if e.details == nil {
+		return nil
+	}
+
+	return e.details.attributes
+}
+
+// ResponseHeaders returns the headers to set on the response
+func (e ErrorWithStatusCode) ResponseHeaders() map[string]interface{} {
+	if e.details == nil {
+		return nil
+	}
+
+	return e.details.headers
+}
+
+// ResponseContentType returns the content type of the response body, if set
+func (e ErrorWithStatusCode) ResponseContentType() string {
+	if e.details == nil {
+		return ""
+	}
+
+	return e.details.contentType
+}
+
+// ResponseBody returns the response body, if set. Otherwise, the error message is sent
+func (e ErrorWithStatusCode) ResponseBody() []byte {
+	if e.details == nil {
+		return nil
+	}
+
+	return e.details.body
+}
+
+// WithHeader returns a copy of the error which sets the header on the response
+func (e ErrorWithStatusCode) WithHeader(key string, value interface{}) *ErrorWithStatusCode {
+	e.details = e.details.clone()
+	e.details.headers[key] = value
+
+	return &e
+}
+
+// WithBody returns a copy of the error which responds with the given body rather than the error message
+func (e ErrorWithStatusCode) WithBody(contentType string, body []byte) *ErrorWithStatusCode {
+	e.details = e.details.clone()
+	e.details.contentType = contentType
+	e.details.body = body
+
+	return &e
+}
+
+// Unwrap returns the underlying error, allowing errors.Is and errors.As to inspect it
+func (e ErrorWithStatusCode) Unwrap() error {
+	return e.error
+}
+
+// Is matches errors with status code by their status code, so that errors.Is(NewErrNotFound("..."),
+// ErrNotFound) holds
+func (e ErrorWithStatusCode) Is(target error) bool {
+	switch typedTarget := target.(type) {
+	case ErrorWithStatusCode:
+		return typedTarget.statusCode == e.statusCode
+	case *ErrorWithStatusCode:
+		return typedTarget != nil && typedTarget.statusCode == e.statusCode
+	}
+
+	return false
+}
+
 // Error returns the error message
 func (e ErrorWithStatusCode) Error() string {
 	if e.error != nil {
@@ -95,32 +207,111 @@ func (e ErrorWithStatusCode) Error() string {
 	return message
 }
 
-{{range .}}
-// {{. | StatusToError}} is a {{.}} Error
-var {{. | StatusToError}} = ErrorWithStatusCode{statusCode: http.{{.}}}
+{{- if .ExtraStatuses}}
+
+// Status codes which are not defined by net/http
+const (
+{{- range .ExtraStatuses}}
+	{{.Name}} = {{.Code}}
+{{- end}}
+)
+{{- end}}
+{{range .Statuses}}
+// {{.ErrorName}} is a {{.Name}} Error
+var {{.ErrorName}} = ErrorWithStatusCode{statusCode: {{.CodeExpression}}}
 
-// New{{. | StatusToError}} returns a new {{. | StatusToError}} with custom error message
-func New{{. | StatusToError}}(message string) error {
+// New{{.ErrorName}} returns a new {{.ErrorName}} with custom error message
+func New{{.ErrorName}}(message string) error {
 	return &ErrorWithStatusCode{
 		error: errors.New(message),
-		statusCode: http.{{.}},
+		statusCode: {{.CodeExpression}},
 	}
 }
 
-// Wrap{{. | StatusToError}} returns a new {{. | StatusToError}}, wrapping an existing error
-func Wrap{{. | StatusToError}}(err error) error {
+// New{{.ErrorName}}f returns a new {{.ErrorName}} with a formatted error message
+func New{{.ErrorName}}f(format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error: fmt.Errorf(format, args...),
+		statusCode: {{.CodeExpression}},
+	}
+}
+
+// New{{.ErrorName}}With returns a new {{.ErrorName}} with custom error message and key / value attributes
+func New{{.ErrorName}}With(message string, attributes ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error: errors.New(message),
+		statusCode: {{.CodeExpression}},
+		details: &errorDetails{attributes: attributes},
+	}
+}
+
+// Wrap{{.ErrorName}} returns a new {{.ErrorName}}, wrapping an existing error
+func Wrap{{.ErrorName}}(err error) error {
 	return &ErrorWithStatusCode{
 		error: err,
-		statusCode: http.{{.}},
+		statusCode: {{.CodeExpression}},
+	}
+}
+
+// Wrap{{.ErrorName}}f returns a new {{.ErrorName}}, wrapping an existing error with a formatted message
+func Wrap{{.ErrorName}}f(err error, format string, args ...interface{}) error {
+	return &ErrorWithStatusCode{
+		error: fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		statusCode: {{.CodeExpression}},
 	}
 }
 {{end}}
 
+// ErrorAttributes returns the key / value attributes of all errors in the chain of err, outermost
+// first. They can be passed as is to the structured logger functions
+func ErrorAttributes(err error) []interface{} {
+	var attributes []interface{}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		if withAttributes, ok := err.(WithAttributes); ok {
+			attributes = append(attributes, withAttributes.Attributes()...)
+		}
+	}
+
+	return attributes
+}
+
+// StatusCodeOf returns the status code of the first error with status code in the chain of err. A
+// nil error has status code 200, and an error without one has status code 500
+func StatusCodeOf(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	var withStatusCode WithStatusCode
+	if errors.As(err, &withStatusCode) {
+		return withStatusCode.StatusCode()
+	}
+
+	return http.StatusInternalServerError
+}
+
+// NewErrorWithStatusCode returns a new error with any status code and a custom error message
+func NewErrorWithStatusCode(statusCode int, message string) error {
+	return &ErrorWithStatusCode{
+		error: errors.New(message),
+		statusCode: statusCode,
+	}
+}
+
+// WrapErrorWithStatusCode returns a new error with any status code, wrapping an existing error
+func WrapErrorWithStatusCode(statusCode int, err error) error {
+	return &ErrorWithStatusCode{
+		error: err,
+		statusCode: statusCode,
+	}
+}
+
 func GetByStatusCode(statusCode int) func(string) error {
 	switch statusCode {
-{{range .}}
-	case http.{{.}}:
-		return New{{. | StatusToError}}
+{{range .Statuses}}
+	case {{.CodeExpression}}:
+		return New{{.ErrorName}}
 {{end}}
 	}
 	return nil
@@ -128,17 +319,17 @@ func GetByStatusCode(statusCode int) func(string) error {
 
 func GetWrapByStatusCode(statusCode int) func(error) error {
 	switch statusCode {
-{{range .}}
-	case http.{{.}}:
-		return Wrap{{. | StatusToError}}
+{{range .Statuses}}
+	case {{.CodeExpression}}:
+		return Wrap{{.ErrorName}}
 {{end}}
 	}
 	return nil
 }
 
 var defaultMessages = map[int]string{
-{{- range .}}
-	http.{{.}}: "{{. | HumanStatus}}",
+{{- range .Statuses}}
+	{{.CodeExpression}}: "{{.Message}}",
 {{- end}}
 }
 `
@@ -147,6 +338,9 @@ var defaultMessages = map[int]string{
 var (
 	// Add space between camel case
 	humanRe = regexp.MustCompile("([a-z])([A-Z])")
+
+	// Extra status names must be valid exported identifiers once prefixed
+	nameRe = regexp.MustCompile("^[A-Z][A-Za-z0-9]*$")
 )
 
 // StatusToError convert http status name to error name
@@ -161,15 +355,40 @@ func HumanStatus(status string) string {
 	return humanRe.ReplaceAllString(status[len(statusPrefix):], "$1 $2")
 }
 
-func main() {
-	flag.Parse() // Support -h, --help
+// status is a status code for which errors are generated
+type status struct {
+	Name           string
+	ErrorName      string
+	Code           int
+	CodeExpression string
+	Message        string
+}
+
+// extraStatus is a status code defined in the extra definitions file
+type extraStatus struct {
 
+	// Name is the name of the status, without the "Status" prefix (e.g. "ClientClosedRequest")
+	Name string `json:"name"`
+
+	// Code is the status code
+	Code int `json:"code"`
+
+	// Message is the default error message. Defaults to the name, split into words
+	Message string `json:"message,omitempty"`
+}
+
+type templateData struct {
+	Statuses      []status
+	ExtraStatuses []status
+}
+
+func readNetHTTPStatuses() []status {
 	pkg, err := importer.Default().Import("net/http")
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	var names []string
+	var statuses []status
 	for _, name := range pkg.Scope().Names() {
 		if !strings.HasPrefix(name, statusPrefix) || name == "StatusOK" {
 			continue
@@ -180,15 +399,89 @@ func main() {
 			continue
 		}
 
-		names = append(names, name)
+		code, _ := constant.Int64Val(obj.(*types.Const).Val())
+
+		statuses = append(statuses, status{
+			Name:           name,
+			ErrorName:      StatusToError(name),
+			Code:           int(code),
+			CodeExpression: "http." + name,
+			Message:        HumanStatus(name),
+		})
 	}
-	sort.Strings(names)
 
-	funcMap := template.FuncMap{
-		"StatusToError": StatusToError,
-		"HumanStatus":   HumanStatus,
+	return statuses
+}
+
+func readExtraStatuses(path string, netHTTPStatuses []status) []status {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var extraStatuses []extraStatus
+	if err := json.Unmarshal(contents, &extraStatuses); err != nil {
+		log.Fatalf("Failed to parse %s: %s", path, err)
+	}
+
+	knownCodes := map[int]string{}
+	for _, netHTTPStatus := range netHTTPStatuses {
+		knownCodes[netHTTPStatus.Code] = netHTTPStatus.Name
+	}
+
+	var statuses []status
+	for _, extraStatus := range extraStatuses {
+		name := statusPrefix + extraStatus.Name
+
+		if !nameRe.MatchString(extraStatus.Name) {
+			log.Fatalf("Invalid status name %q", extraStatus.Name)
+		}
+
+		if extraStatus.Code < 100 || extraStatus.Code > 999 {
+			log.Fatalf("Invalid status code %d for %s", extraStatus.Code, name)
+		}
+
+		if knownName, found := knownCodes[extraStatus.Code]; found {
+			log.Fatalf("Status code %d of %s is already defined by %s", extraStatus.Code, name, knownName)
+		}
+
+		knownCodes[extraStatus.Code] = name
+
+		message := extraStatus.Message
+		if message == "" {
+			message = HumanStatus(name)
+		}
+
+		statuses = append(statuses, status{
+			Name:           name,
+			ErrorName:      StatusToError(name),
+			Code:           extraStatus.Code,
+			CodeExpression: name,
+			Message:        message,
+		})
 	}
-	codeTemplate, err := template.New("").Funcs(funcMap).Parse(codeTemplateText)
+
+	return statuses
+}
+
+func main() {
+	extraPath := flag.String("extra", "", "JSON file of status codes to generate in addition to those of net/http")
+	flag.Parse()
+
+	data := templateData{
+		Statuses: readNetHTTPStatuses(),
+	}
+
+	if *extraPath != "" {
+		data.ExtraStatuses = readExtraStatuses(*extraPath, data.Statuses)
+		data.Statuses = append(data.Statuses, data.ExtraStatuses...)
+	}
+
+	sort.Slice(data.Statuses, func(i, j int) bool {
+		return data.Statuses[i].Name < data.Statuses[j].Name
+	})
+
+	codeTemplate, err := template.New("").Parse(codeTemplateText)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -198,7 +491,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	if err := codeTemplate.Execute(out, names); err != nil {
+	if err := codeTemplate.Execute(out, data); err != nil {
 		log.Fatal(err)
 	}
 }
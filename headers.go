@@ -0,0 +1,135 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"fmt"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// Headers holds the headers of events and responses. Header names are case insensitive - lookups
+// find headers regardless of the case they were stored in, and Set and Add store headers under their
+// canonical names (e.g. Content-Type). Headers which repeat are held as a []string
+type Headers map[string]interface{}
+
+// Get returns the value of the header, or nil if it is not present
+func (h Headers) Get(key string) interface{} {
+	value, _ := h.Lookup(key)
+	return value
+}
+
+// Lookup returns the value of the header, and whether it is present
+func (h Headers) Lookup(key string) (interface{}, bool) {
+	if value, found := h[key]; found {
+		return value, true
+	}
+
+	if value, found := h[textproto.CanonicalMIMEHeaderKey(key)]; found {
+		return value, true
+	}
+
+	for headerKey, value := range h {
+		if strings.EqualFold(headerKey, key) {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// GetString returns the (first) value of the header as a string, or an empty string if it is not
+// present
+func (h Headers) GetString(key string) string {
+	value, _ := formatHeaderValues(h.Get(key))
+	if len(value) == 0 {
+		return ""
+	}
+
+	return value[0]
+}
+
+// Values returns all values of the header
+func (h Headers) Values(key string) []string {
+	return convertToStringValues(h.Get(key))
+}
+
+// Set sets the header, replacing any value it had under any case
+func (h Headers) Set(key string, value interface{}) {
+	h.Del(key)
+	h[textproto.CanonicalMIMEHeaderKey(key)] = value
+}
+
+// Add adds a value to the header, keeping the values it already has
+func (h Headers) Add(key string, value string) {
+	existingValues := h.Values(key)
+	if len(existingValues) == 0 {
+		h.Set(key, value)
+		return
+	}
+
+	h.Set(key, append(existingValues[:len(existingValues):len(existingValues)], value))
+}
+
+// Del deletes the header under any case
+func (h Headers) Del(key string) {
+	for headerKey := range h {
+		if strings.EqualFold(headerKey, key) {
+			delete(h, headerKey)
+		}
+	}
+}
+
+// Clone returns a copy of the headers
+func (h Headers) Clone() Headers {
+	if h == nil {
+		return nil
+	}
+
+	clone := make(Headers, len(h))
+	for headerKey, value := range h {
+		clone[headerKey] = value
+	}
+
+	return clone
+}
+
+// formatHeaderValues formats the value of a header for the wire, returning false for values of
+// unsupported types
+func formatHeaderValues(value interface{}) ([]string, bool) {
+	switch typedValue := value.(type) {
+	case nil:
+		return nil, true
+	case string:
+		return []string{typedValue}, true
+	case []byte:
+		return []string{string(typedValue)}, true
+	case []string:
+		return typedValue, true
+	case int:
+		return []string{strconv.Itoa(typedValue)}, true
+	case int64:
+		return []string{strconv.FormatInt(typedValue, 10)}, true
+	case bool:
+		return []string{strconv.FormatBool(typedValue)}, true
+	case fmt.Stringer:
+		return []string{typedValue.String()}, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHeaders(t *testing.T) {
+	headers := Headers{"content-type": "application/json"}
+
+	for _, key := range []string{"content-type", "Content-Type", "CONTENT-TYPE"} {
+		if headers.GetString(key) != "application/json" {
+			t.Fatalf("Failed to get header by %s", key)
+		}
+	}
+
+	headers.Set("CONTENT-TYPE", "text/plain")
+	if !reflect.DeepEqual(headers, Headers{"Content-Type": "text/plain"}) {
+		t.Fatalf("Set should replace the header under its canonical name: %v", headers)
+	}
+
+	headers.Add("set-cookie", "a=1")
+	headers.Add("Set-Cookie", "b=2")
+	if values := headers.Values("SET-COOKIE"); !reflect.DeepEqual(values, []string{"a=1", "b=2"}) {
+		t.Fatalf("Bad repeated header values: %v", values)
+	}
+
+	if headers.GetString("Set-Cookie") != "a=1" {
+		t.Fatalf("Bad first value of repeated header: %s", headers.GetString("Set-Cookie"))
+	}
+
+	headers.Del("content-TYPE")
+	if _, found := headers.Lookup("Content-Type"); found {
+		t.Fatalf("Header should have been deleted")
+	}
+
+	if Headers(nil).Get("X-Missing") != nil {
+		t.Fatalf("Nil headers should have no values")
+	}
+}
+
+func TestMemoryEventHeadersAreCaseInsensitive(t *testing.T) {
+	event := &MemoryEvent{
+		Headers: map[string]interface{}{"content-type": "application/json", "X-Count": 3},
+	}
+
+	if event.GetHeaderString("Content-Type") != "application/json" {
+		t.Fatalf("Failed to get header by canonical name")
+	}
+
+	if count, err := event.GetHeaderInt("x-count"); err != nil || count != 3 {
+		t.Fatalf("Failed to get header by lower case name: %d, %v", count, err)
+	}
+}
@@ -16,13 +16,142 @@ limitations under the License.
 
 package nuclio
 
+import (
+	"time"
+)
+
+// MemoryEvent is an event held in memory, whose attributes are all settable. It is mostly used to
+// test handlers, either built with NewMemoryEvent and its With* methods or as a struct literal. Events
+// whose ShardID is nil arrived from no particular shard, and report a shard ID of -1
 type MemoryEvent struct {
 	AbstractEvent
-	Method      string
-	ContentType string
-	Body        []byte
-	Headers     map[string]interface{}
-	Path        string
+	Method         string
+	ContentType    string
+	Body           []byte
+	Headers        Headers
+	Path           string
+	URL            string
+	Fields         map[string]interface{}
+	Timestamp      time.Time
+	ShardID        *int
+	TotalNumShards int
+	Offset         int
+	Topic          string
+	Type           string
+	TypeVersion    string
+	Version        string
+	LastInBatch    bool
+}
+
+// NewMemoryEvent creates a memory event timestamped now, which arrived from no particular shard
+func NewMemoryEvent() *MemoryEvent {
+	return &MemoryEvent{
+		Headers:   Headers{},
+		Fields:    map[string]interface{}{},
+		Timestamp: time.Now(),
+	}
+}
+
+// WithID sets the ID of the event
+func (me *MemoryEvent) WithID(id ID) *MemoryEvent {
+	me.SetID(id)
+	return me
+}
+
+// WithTriggerInfo sets the information about the trigger which triggered the event
+func (me *MemoryEvent) WithTriggerInfo(triggerInfoProvider TriggerInfoProvider) *MemoryEvent {
+	me.SetTriggerInfoProvider(triggerInfoProvider)
+	return me
+}
+
+// WithTrigger sets the class (e.g. async), kind (e.g. kafka-cluster) and name of the trigger which
+// triggered the event
+func (me *MemoryEvent) WithTrigger(class string, kind string, name string) *MemoryEvent {
+	return me.WithTriggerInfo(&TriggerInfo{Class: class, Kind: kind, Name: name})
+}
+
+// WithMethod sets the method of the event
+func (me *MemoryEvent) WithMethod(method string) *MemoryEvent {
+	me.Method = method
+	return me
+}
+
+// WithPath sets the path of the event
+func (me *MemoryEvent) WithPath(path string) *MemoryEvent {
+	me.Path = path
+	return me
+}
+
+// WithURL sets the URL of the event
+func (me *MemoryEvent) WithURL(url string) *MemoryEvent {
+	me.URL = url
+	return me
+}
+
+// WithBody sets the body of the event and its content type
+func (me *MemoryEvent) WithBody(contentType string, body []byte) *MemoryEvent {
+	me.ContentType = contentType
+	me.Body = body
+	return me
+}
+
+// WithHeader sets a header of the event
+func (me *MemoryEvent) WithHeader(key string, value interface{}) *MemoryEvent {
+	if me.Headers == nil {
+		me.Headers = Headers{}
+	}
+
+	me.Headers.Set(key, value)
+	return me
+}
+
+// WithField sets a field of the event
+func (me *MemoryEvent) WithField(key string, value interface{}) *MemoryEvent {
+	if me.Fields == nil {
+		me.Fields = map[string]interface{}{}
+	}
+
+	me.Fields[key] = value
+	return me
+}
+
+// WithTimestamp sets when the event originated
+func (me *MemoryEvent) WithTimestamp(timestamp time.Time) *MemoryEvent {
+	me.Timestamp = timestamp
+	return me
+}
+
+// WithShard sets the ID of the shard from which the event arrived, and the total number of shards
+func (me *MemoryEvent) WithShard(shardID int, totalNumShards int) *MemoryEvent {
+	me.ShardID = &shardID
+	me.TotalNumShards = totalNumShards
+	return me
+}
+
+// WithTopic sets the topic of the event, and its offset within the topic (or shard)
+func (me *MemoryEvent) WithTopic(topic string, offset int) *MemoryEvent {
+	me.Topic = topic
+	me.Offset = offset
+	return me
+}
+
+// WithType sets the type of the event and the version of the type
+func (me *MemoryEvent) WithType(eventType string, typeVersion string) *MemoryEvent {
+	me.Type = eventType
+	me.TypeVersion = typeVersion
+	return me
+}
+
+// WithVersion sets the version of the event
+func (me *MemoryEvent) WithVersion(version string) *MemoryEvent {
+	me.Version = version
+	return me
+}
+
+// WithLastInBatch sets whether the event is the last event in a trigger specific batch
+func (me *MemoryEvent) WithLastInBatch(lastInBatch bool) *MemoryEvent {
+	me.LastInBatch = lastInBatch
+	return me
 }
 
 func (me *MemoryEvent) GetMethod() string {
@@ -42,6 +171,11 @@ func (me *MemoryEvent) GetContentType() string {
 	return me.ContentType
 }
 
+// GetSpecifiedContentType returns the content type of the event, or an empty string if it has none
+func (me *MemoryEvent) GetSpecifiedContentType() string {
+	return me.ContentType
+}
+
 func (me *MemoryEvent) GetBody() []byte {
 	return me.Body
 }
@@ -50,13 +184,99 @@ func (me *MemoryEvent) GetPath() string {
 	return me.Path
 }
 
+func (me *MemoryEvent) GetURL() string {
+	return me.URL
+}
+
+func (me *MemoryEvent) GetTimestamp() time.Time {
+	if me.Timestamp.IsZero() {
+		return me.AbstractEvent.GetTimestamp()
+	}
+	return me.Timestamp
+}
+
+func (me *MemoryEvent) GetShardID() int {
+	if me.ShardID == nil {
+		return me.AbstractEvent.GetShardID()
+	}
+	return *me.ShardID
+}
+
+func (me *MemoryEvent) GetTotalNumShards() int {
+	return me.TotalNumShards
+}
+
+func (me *MemoryEvent) GetType() string {
+	return me.Type
+}
+
+func (me *MemoryEvent) GetTypeVersion() string {
+	return me.TypeVersion
+}
+
+func (me *MemoryEvent) GetVersion() string {
+	return me.Version
+}
+
+func (me *MemoryEvent) GetLastInBatch() bool {
+	return me.LastInBatch
+}
+
+func (me *MemoryEvent) GetOffset() int {
+	return me.Offset
+}
+
+func (me *MemoryEvent) GetTopic() string {
+	return me.Topic
+}
+
 func (me *MemoryEvent) GetHeaders() map[string]interface{} {
 	return me.Headers
 }
 
 func (me *MemoryEvent) GetHeader(key string) interface{} {
-	if val, ok := me.Headers[key]; ok {
+	if val, ok := me.Headers.Lookup(key); ok {
 		return val
 	}
 	return ""
 }
+
+func (me *MemoryEvent) GetHeaderByteSlice(key string) []byte {
+	value, _ := convertToString(me.GetHeader(key))
+	return []byte(value)
+}
+
+func (me *MemoryEvent) GetHeaderString(key string) string {
+	value, _ := convertToString(me.GetHeader(key))
+	return value
+}
+
+func (me *MemoryEvent) GetHeaderInt(key string) (int, error) {
+	value, found := me.Headers.Lookup(key)
+	if !found {
+		return 0, ErrTypeConversion
+	}
+	return convertToIntAccessorValue(value)
+}
+
+func (me *MemoryEvent) GetFields() map[string]interface{} {
+	return me.Fields
+}
+
+func (me *MemoryEvent) GetField(key string) interface{} {
+	return me.Fields[key]
+}
+
+func (me *MemoryEvent) GetFieldByteSlice(key string) []byte {
+	value, _ := convertToString(me.GetField(key))
+	return []byte(value)
+}
+
+func (me *MemoryEvent) GetFieldString(key string) string {
+	value, _ := convertToString(me.GetField(key))
+	return value
+}
+
+func (me *MemoryEvent) GetFieldInt(key string) (int, error) {
+	return convertToIntAccessorValue(me.GetField(key))
+}
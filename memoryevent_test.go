@@ -0,0 +1,95 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryEventBuilder(t *testing.T) {
+	timestamp := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	event := NewMemoryEvent().
+		WithID("e1").
+		WithTrigger("async", "kafka-cluster", "orders").
+		WithBody(ContentTypeJSON, []byte(`{"quantity": 3}`)).
+		WithHeader("x-request-id", "r1").
+		WithField("key", "k1").
+		WithTimestamp(timestamp).
+		WithShard(2, 8).
+		WithTopic("orders", 1234).
+		WithType("order.created", "v2").
+		WithVersion("1").
+		WithLastInBatch(true)
+
+	var _ Event = event
+
+	if event.GetID() != "e1" ||
+		event.GetTriggerInfo().GetKind() != "kafka-cluster" ||
+		event.GetContentType() != ContentTypeJSON ||
+		event.GetHeaderString("X-Request-Id") != "r1" ||
+		event.GetFieldString("key") != "k1" ||
+		!event.GetTimestamp().Equal(timestamp) ||
+		event.GetShardID() != 2 ||
+		event.GetTotalNumShards() != 8 ||
+		event.GetTopic() != "orders" ||
+		event.GetOffset() != 1234 ||
+		event.GetType() != "order.created" ||
+		event.GetTypeVersion() != "v2" ||
+		event.GetVersion() != "1" ||
+		!event.GetLastInBatch() {
+		t.Fatalf("Bad built event: %+v", event)
+	}
+
+	// all attributes survive recording and replaying
+	data, err := MarshalEvent(event)
+	if err != nil {
+		t.Fatalf("Failed to marshal event: %s", err)
+	}
+
+	replayedEvent, err := UnmarshalEvent(data)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal event: %s", err)
+	}
+
+	if replayedEvent.GetTotalNumShards() != 8 ||
+		replayedEvent.GetType() != "order.created" ||
+		replayedEvent.GetTypeVersion() != "v2" ||
+		replayedEvent.GetVersion() != "1" ||
+		!replayedEvent.GetLastInBatch() {
+		t.Fatalf("Bad replayed event: %+v", replayedEvent)
+	}
+}
+
+func TestNewMemoryEventDefaults(t *testing.T) {
+	event := NewMemoryEvent()
+
+	if event.GetShardID() != -1 || event.GetTimestamp().IsZero() || event.GetTriggerInfo() != nil {
+		t.Fatalf("Bad defaults: %+v", event)
+	}
+
+	// events built as literals also arrive from no particular shard
+	if shardID := (&MemoryEvent{}).GetShardID(); shardID != -1 {
+		t.Fatalf("Bad shard ID of literal event: %d", shardID)
+	}
+
+	// the timestamp is fixed when the event is created
+	if !event.GetTimestamp().Equal(event.GetTimestamp()) {
+		t.Fatalf("Timestamp should not change between calls")
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nuclio/nuclio-sdk-go"
+)
+
+// Authenticator checks the credentials of an event, returning an error if they are missing or
+// invalid. Errors without a status code are responded to with ErrUnauthorized
+type Authenticator func(context *nuclio.Context, event nuclio.Event) error
+
+// Auth returns a middleware which only passes events the authenticator accepts to the handler
+func Auth(authenticator Authenticator) Middleware {
+	return func(handler nuclio.Handler) nuclio.Handler {
+		return func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+			if err := authenticator(context, event); err != nil {
+				var withStatusCode nuclio.WithStatusCode
+				if !errors.As(err, &withStatusCode) {
+					err = nuclio.WrapErrUnauthorized(err)
+				}
+
+				return nil, err
+			}
+
+			return handler(context, event)
+		}
+	}
+}
+
+// BearerToken returns an authenticator which passes the bearer token of the Authorization header to
+// validate. Events without a bearer token are challenged for one
+func BearerToken(validate func(context *nuclio.Context, token string) error) Authenticator {
+	return func(context *nuclio.Context, event nuclio.Event) error {
+		scheme, token, _ := strings.Cut(event.GetHeaderString("Authorization"), " ")
+		token = strings.TrimSpace(token)
+
+		if !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return nuclio.NewErrUnauthorizedWithChallenge("Bearer")
+		}
+
+		return validate(context, token)
+	}
+}
+
+// APIKey returns an authenticator which accepts events whose header holds one of the keys
+func APIKey(header string, keys ...string) Authenticator {
+	return func(context *nuclio.Context, event nuclio.Event) error {
+		key := event.GetHeaderString(header)
+		if key == "" {
+			return nuclio.NewErrUnauthorized(fmt.Sprintf("Missing %s header", header))
+		}
+
+		// compare all keys in constant time, so that timing doesn't leak which key was close
+		accepted := 0
+		for _, acceptedKey := range keys {
+			accepted |= subtle.ConstantTimeCompare([]byte(key), []byte(acceptedKey))
+		}
+
+		if accepted == 0 {
+			return nuclio.NewErrUnauthorized(fmt.Sprintf("Invalid %s header", header))
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/nuclio/nuclio-sdk-go"
+)
+
+// MaxBodySize returns a middleware which fails events whose body is larger than maxBodySize bytes with
+// ErrRequestEntityTooLarge, without passing them to the handler
+func MaxBodySize(maxBodySize int) Middleware {
+	return func(handler nuclio.Handler) nuclio.Handler {
+		return func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+			if bodySize := len(event.GetBody()); bodySize > maxBodySize {
+				return nil, nuclio.NewErrRequestEntityTooLarge(fmt.Sprintf("Body of %d bytes exceeds the maximum of %d bytes",
+					bodySize,
+					maxBodySize))
+			}
+
+			return handler(context, event)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Middleware for nuclio handlers. A middleware wraps a handler to add behavior before and after it
+handles events - recovering from panics, logging requests, measuring handling time, authenticating
+and limiting the size of bodies. Middleware are composed with Chain:
+
+	handler := middleware.Chain(handleOrder,
+		middleware.Recover(),
+		middleware.Logging(),
+		middleware.MaxBodySize(1024*1024))
+*/
+package middleware
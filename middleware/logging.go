@@ -0,0 +1,109 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nuclio/nuclio-sdk-go"
+)
+
+// ServerTimingHeader is the header in which Timing reports how long the handler took
+const ServerTimingHeader = "Server-Timing"
+
+// TimingObserver is notified of how long the handler took to handle an event
+type TimingObserver func(context *nuclio.Context, event nuclio.Event, duration time.Duration, err error)
+
+// Logging returns a middleware which logs every event handled through the context logger, with its
+// method, path, status code and duration. Server errors are logged as warnings, and everything else
+// in debug
+func Logging() Middleware {
+	return func(handler nuclio.Handler) nuclio.Handler {
+		return func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+			if context == nil || context.Logger == nil {
+				return handler(context, event)
+			}
+
+			startTime := time.Now()
+			result, err := handler(context, event)
+
+			statusCode := getResultStatusCode(result, err)
+			vars := []interface{}{
+				"id", event.GetID(),
+				"method", event.GetMethod(),
+				"path", event.GetPath(),
+				"statusCode", statusCode,
+				"duration", time.Since(startTime).String(),
+			}
+
+			if err != nil {
+				vars = append(vars, "err", err.Error())
+			}
+
+			if nuclio.IsServerErrorStatusCode(statusCode) {
+				context.Logger.WarnWith("Failed handling event", vars...)
+			} else {
+				context.Logger.DebugWith("Handled event", vars...)
+			}
+
+			return result, err
+		}
+	}
+}
+
+// Timing returns a middleware which measures how long the handler takes, notifying the observer (if
+// not nil) and reporting the duration in the Server-Timing header of Response results
+func Timing(observer TimingObserver) Middleware {
+	return func(handler nuclio.Handler) nuclio.Handler {
+		return func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+			startTime := time.Now()
+			result, err := handler(context, event)
+			duration := time.Since(startTime)
+
+			if observer != nil {
+				observer(context, event, duration, err)
+			}
+
+			serverTiming := fmt.Sprintf("handler;dur=%.3f", float64(duration)/float64(time.Millisecond))
+
+			switch typedResult := result.(type) {
+			case nuclio.Response:
+				setResponseHeader(&typedResult, ServerTimingHeader, serverTiming)
+				result = typedResult
+			case *nuclio.Response:
+				if typedResult != nil {
+					setResponseHeader(typedResult, ServerTimingHeader, serverTiming)
+				}
+			}
+
+			return result, err
+		}
+	}
+}
+
+func setResponseHeader(response *nuclio.Response, key string, value string) {
+
+	// copy the headers, as responses are often built from shared header maps
+	headers := response.Headers.Clone()
+	if headers == nil {
+		headers = nuclio.Headers{}
+	}
+
+	headers.Set(key, value)
+	response.Headers = headers
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/nuclio/nuclio-sdk-go"
+)
+
+// Middleware wraps a handler, returning a handler which adds behavior around it
+type Middleware func(nuclio.Handler) nuclio.Handler
+
+// Chain wraps the handler with the middleware. The first middleware is the outermost, and so sees
+// events first and results last
+func Chain(handler nuclio.Handler, middleware ...Middleware) nuclio.Handler {
+	for middlewareIndex := len(middleware) - 1; middlewareIndex >= 0; middlewareIndex-- {
+		handler = middleware[middlewareIndex](handler)
+	}
+
+	return handler
+}
+
+// getResultStatusCode returns the status code the processor responds with for a handler's result
+func getResultStatusCode(result interface{}, err error) int {
+	if err != nil {
+		return nuclio.StatusCodeOf(err)
+	}
+
+	switch typedResult := result.(type) {
+	case nuclio.Response:
+		if typedResult.StatusCode != 0 {
+			return typedResult.StatusCode
+		}
+	case *nuclio.Response:
+		if typedResult != nil && typedResult.StatusCode != 0 {
+			return typedResult.StatusCode
+		}
+	}
+
+	return http.StatusOK
+}
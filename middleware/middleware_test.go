@@ -0,0 +1,194 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuclio/nuclio-sdk-go"
+	"github.com/nuclio/nuclio-sdk-go/nucliotest"
+
+	"github.com/nuclio/logger"
+)
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+
+	tracing := func(name string) Middleware {
+		return func(handler nuclio.Handler) nuclio.Handler {
+			return func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+				calls = append(calls, name+" before")
+				result, err := handler(context, event)
+				calls = append(calls, name+" after")
+				return result, err
+			}
+		}
+	}
+
+	handler := Chain(func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+		calls = append(calls, "handler")
+		return nil, nil
+	}, tracing("outer"), tracing("inner"))
+
+	if _, err := handler(nil, &nuclio.MemoryEvent{}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if strings.Join(calls, ",") != "outer before,inner before,handler,inner after,outer after" {
+		t.Fatalf("Bad call order: %v", calls)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	testLogger := nucliotest.NewLogger("test")
+
+	handler := Chain(func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+		panic("boom")
+	}, Recover())
+
+	result, err := handler(&nuclio.Context{Logger: testLogger}, &nuclio.MemoryEvent{})
+	if result != nil || !errors.Is(err, nuclio.ErrInternalServerError) {
+		t.Fatalf("Expected internal server error, got %v, %v", result, err)
+	}
+
+	if len(testLogger.RecordsWithLevel(logger.LevelError)) != 1 {
+		t.Fatalf("Expected panic to be logged")
+	}
+}
+
+func TestLoggingAndTiming(t *testing.T) {
+	testLogger := nucliotest.NewLogger("test")
+
+	var observedDuration time.Duration
+	handler := Chain(func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+		if event.GetPath() == "/fail" {
+			return nil, nuclio.NewErrBadGateway("upstream failed")
+		}
+
+		return nuclio.Response{StatusCode: http.StatusCreated}, nil
+	}, Logging(), Timing(func(context *nuclio.Context, event nuclio.Event, duration time.Duration, err error) {
+		observedDuration = duration
+	}))
+
+	context := &nuclio.Context{Logger: testLogger}
+
+	result, err := handler(context, &nuclio.MemoryEvent{Path: "/ok"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	serverTiming, _ := result.(nuclio.Response).Headers[ServerTimingHeader].(string)
+	if !strings.HasPrefix(serverTiming, "handler;dur=") || observedDuration <= 0 {
+		t.Fatalf("Bad timing: %q, %s", serverTiming, observedDuration)
+	}
+
+	if _, err := handler(context, &nuclio.MemoryEvent{Path: "/fail"}); err == nil {
+		t.Fatalf("Expected error")
+	}
+
+	debugRecords := testLogger.RecordsWithLevel(logger.LevelDebug)
+	warnRecords := testLogger.RecordsWithLevel(logger.LevelWarn)
+	if len(debugRecords) != 1 || len(warnRecords) != 1 {
+		t.Fatalf("Expected one debug and one warning record, got %d and %d", len(debugRecords), len(warnRecords))
+	}
+
+	if statusCode := getRecordVar(debugRecords[0], "statusCode"); statusCode != http.StatusCreated {
+		t.Fatalf("Bad logged status code: %v", statusCode)
+	}
+
+	if statusCode := getRecordVar(warnRecords[0], "statusCode"); statusCode != http.StatusBadGateway {
+		t.Fatalf("Bad logged status code: %v", statusCode)
+	}
+}
+
+func TestAuth(t *testing.T) {
+	okHandler := func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+		return "ok", nil
+	}
+
+	apiKeyHandler := Chain(okHandler, Auth(APIKey("X-Api-Key", "k1", "k2")))
+
+	if _, err := apiKeyHandler(nil, &nuclio.MemoryEvent{
+		Headers: map[string]interface{}{"X-Api-Key": "k2"},
+	}); err != nil {
+		t.Fatalf("Valid key was rejected: %s", err)
+	}
+
+	for _, headers := range []map[string]interface{}{
+		nil,
+		{"X-Api-Key": "k3"},
+	} {
+		if _, err := apiKeyHandler(nil, &nuclio.MemoryEvent{Headers: headers}); !errors.Is(err, nuclio.ErrUnauthorized) {
+			t.Fatalf("Expected unauthorized for %v, got %v", headers, err)
+		}
+	}
+
+	bearerHandler := Chain(okHandler, Auth(BearerToken(func(context *nuclio.Context, token string) error {
+		if token != "secret" {
+			return errors.New("Invalid token")
+		}
+
+		return nil
+	})))
+
+	if _, err := bearerHandler(nil, &nuclio.MemoryEvent{
+		Headers: map[string]interface{}{"Authorization": "Bearer secret"},
+	}); err != nil {
+		t.Fatalf("Valid token was rejected: %s", err)
+	}
+
+	_, err := bearerHandler(nil, &nuclio.MemoryEvent{})
+	var withResponse nuclio.WithResponse
+	if !errors.As(err, &withResponse) || withResponse.ResponseHeaders()["WWW-Authenticate"] != "Bearer" {
+		t.Fatalf("Expected bearer challenge, got %v", err)
+	}
+
+	// errors without a status code are unauthorized
+	if _, err := bearerHandler(nil, &nuclio.MemoryEvent{
+		Headers: map[string]interface{}{"Authorization": "Bearer wrong"},
+	}); nuclio.StatusCodeOf(err) != http.StatusUnauthorized {
+		t.Fatalf("Expected unauthorized, got %v", err)
+	}
+}
+
+func TestMaxBodySize(t *testing.T) {
+	handler := Chain(func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+		return "ok", nil
+	}, MaxBodySize(4))
+
+	if _, err := handler(nil, &nuclio.MemoryEvent{Body: []byte("1234")}); err != nil {
+		t.Fatalf("Body within limit was rejected: %s", err)
+	}
+
+	if _, err := handler(nil, &nuclio.MemoryEvent{Body: []byte("12345")}); !errors.Is(err, nuclio.ErrRequestEntityTooLarge) {
+		t.Fatalf("Expected request entity too large, got %v", err)
+	}
+}
+
+func getRecordVar(record nucliotest.LogRecord, key string) interface{} {
+	for varIndex := 0; varIndex+1 < len(record.Vars); varIndex += 2 {
+		if record.Vars[varIndex] == key {
+			return record.Vars[varIndex+1]
+		}
+	}
+
+	return nil
+}
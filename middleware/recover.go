@@ -0,0 +1,48 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"runtime/debug"
+
+	"github.com/nuclio/nuclio-sdk-go"
+)
+
+// Recover returns a middleware which recovers from panics in the handler. The panic is logged with its
+// stack trace and the handler fails with ErrInternalServerError, without exposing the panic to the
+// client
+func Recover() Middleware {
+	return func(handler nuclio.Handler) nuclio.Handler {
+		return func(context *nuclio.Context, event nuclio.Event) (result interface{}, err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					if context != nil && context.Logger != nil {
+						context.Logger.ErrorWith("Handler panicked",
+							"panic", recovered,
+							"path", event.GetPath(),
+							"stack", string(debug.Stack()))
+					}
+
+					result = nil
+					err = nuclio.ErrInternalServerError
+				}
+			}()
+
+			return handler(context, event)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nucliotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nuclio/nuclio-sdk-go"
+)
+
+// ContextOptions controls the context created by the harness. Zero values are replaced by defaults
+type ContextOptions struct {
+	FunctionName        string
+	FunctionVersion     int
+	WorkerID            int
+	WorkerAllocatorName string
+	TriggerClass        string
+	TriggerKind         string
+	TriggerName         string
+	DataBinding         map[string]nuclio.DataBinding
+	UserData            interface{}
+
+	// PlatformConfiguration configures the fake platform of the context (e.g. its retry policy)
+	PlatformConfiguration *nuclio.PlatformConfiguration
+}
+
+// Harness invokes handlers with a context resembling the one created by the processor
+type Harness struct {
+
+	// Context is passed to every handler invocation. It may be modified between invocations
+	Context *nuclio.Context
+
+	// Logger captures everything logged through the context
+	Logger *Logger
+
+	// FakePlatform serves calls made through the platform of the context
+	FakePlatform *FakePlatform
+
+	// TriggerInfo is set on events which carry no trigger information of their own
+	TriggerInfo *nuclio.TriggerInfo
+}
+
+// Result is the outcome of a handler invocation, normalized into what the processor would respond with
+type Result struct {
+	StatusCode  int
+	ContentType string
+	Headers     nuclio.Headers
+	Body        []byte
+
+	// Value is the value returned by the handler, as is
+	Value interface{}
+
+	// Err is the error returned by the handler (or recovered from its panic), as is
+	Err error
+}
+
+// NewHarness creates a new harness
+func NewHarness(options *ContextOptions) (*Harness, error) {
+	if options == nil {
+		options = &ContextOptions{}
+	}
+
+	harnessLogger := NewLogger("")
+
+	fakePlatform, err := NewFakePlatform(harnessLogger, options.PlatformConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create fake platform: %w", err)
+	}
+
+	triggerInfo := &nuclio.TriggerInfo{
+		Class: getStringOrDefault(options.TriggerClass, "sync"),
+		Kind:  getStringOrDefault(options.TriggerKind, "http"),
+		Name:  getStringOrDefault(options.TriggerName, "default-http"),
+	}
+
+	dataBinding := options.DataBinding
+	if dataBinding == nil {
+		dataBinding = map[string]nuclio.DataBinding{}
+	}
+
+	return &Harness{
+		Context: &nuclio.Context{
+			Logger:              harnessLogger,
+			DataBinding:         dataBinding,
+			Platform:            fakePlatform.Platform,
+			WorkerID:            options.WorkerID,
+			UserData:            options.UserData,
+			FunctionName:        getStringOrDefault(options.FunctionName, "test-function"),
+			FunctionVersion:     options.FunctionVersion,
+			TriggerKind:         triggerInfo.Kind,
+			TriggerName:         triggerInfo.Name,
+			WorkerAllocatorName: getStringOrDefault(options.WorkerAllocatorName, "test-allocator"),
+		},
+		Logger:       harnessLogger,
+		FakePlatform: fakePlatform,
+		TriggerInfo:  triggerInfo,
+	}, nil
+}
+
+// Invoke calls the handler with the event and normalizes its result. Panics are recovered and
+// turned into internal server errors, like the processor does
+func (h *Harness) Invoke(handler nuclio.Handler, event nuclio.Event) *Result {
+	if event.GetTriggerInfo() == nil {
+		event.SetTriggerInfoProvider(h.TriggerInfo)
+	}
+
+	return invokeHandler(h.Context, handler, event)
+}
+
+// NormalizeResult converts the return values of a handler into the response the processor would
+// send. Streamed bodies are read until the handler stops streaming
+func NormalizeResult(value interface{}, err error) *Result {
+	result := &Result{
+		StatusCode: http.StatusOK,
+		Headers:    nuclio.Headers{},
+		Value:      value,
+		Err:        err,
+	}
+
+	if err != nil {
+		result.setFromProcessingResult(nuclio.ErrorToProcessingResult(err))
+		return result
+	}
+
+	switch typedValue := value.(type) {
+	case nil:
+	case string:
+		result.ContentType = "text/plain"
+		result.Body = []byte(typedValue)
+	case []byte:
+
+		// the processor doesn't set a content type for byte slices, so its default applies
+		result.ContentType = "text/plain"
+		result.Body = typedValue
+	case nuclio.Response:
+		result.setFromProcessingResult(&typedValue)
+	case nuclio.ProcessingResult:
+		result.setFromProcessingResult(typedValue)
+	case map[string]interface{}, []interface{}:
+		body, err := json.Marshal(typedValue)
+		if err != nil {
+			return NormalizeResult(nil, fmt.Errorf("Failed to encode response: %w", err))
+		}
+
+		result.ContentType = "application/json"
+		result.Body = body
+	default:
+		return NormalizeResult(nil, fmt.Errorf("Unsupported response type: %T", value))
+	}
+
+	return result
+}
+
+// String returns the body as a string
+func (r *Result) String() string {
+	return string(r.Body)
+}
+
+// DecodeJSON decodes the body as JSON into the given value
+func (r *Result) DecodeJSON(value interface{}) error {
+	return json.Unmarshal(r.Body, value)
+}
+
+func (r *Result) setFromProcessingResult(processingResult nuclio.ProcessingResult) {
+	if statusCode := processingResult.GetStatusCode(); statusCode != 0 {
+		r.StatusCode = statusCode
+	}
+
+	r.ContentType = processingResult.GetContentType()
+
+	for headerKey, headerValue := range processingResult.GetHeaders() {
+		r.Headers.Set(headerKey, headerValue)
+	}
+
+	switch typedBody := processingResult.GetBody().(type) {
+	case []byte:
+		r.Body = typedBody
+	case io.Reader:
+		body, err := io.ReadAll(typedBody)
+		if err != nil {
+			r.Err = fmt.Errorf("Failed to read response stream: %w", err)
+		}
+
+		r.Body = body
+	}
+}
+
+func invokeHandler(context *nuclio.Context, handler nuclio.Handler, event nuclio.Event) *Result {
+	value, err := callHandler(context, handler, event)
+	if err != nil {
+		context.Logger.DebugWith("Handler returned an error",
+			append([]interface{}{"err", err.Error()}, nuclio.ErrorAttributes(err)...)...)
+	}
+
+	return NormalizeResult(value, err)
+}
+
+func callHandler(context *nuclio.Context, handler nuclio.Handler, event nuclio.Event) (value interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			context.Logger.ErrorWith("Handler panicked", "recovered", recovered)
+			value, err = nil, nuclio.NewErrInternalServerError(fmt.Sprintf("Handler panicked: %v", recovered))
+		}
+	}()
+
+	return handler(context, event)
+}
+
+func getStringOrDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
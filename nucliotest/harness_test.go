@@ -0,0 +1,154 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nucliotest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/nuclio/nuclio-sdk-go"
+
+	"github.com/nuclio/logger"
+)
+
+func TestInvoke(t *testing.T) {
+	harness, err := NewHarness(&ContextOptions{FunctionName: "greeter", WorkerID: 3})
+	if err != nil {
+		t.Fatalf("Failed to create harness: %s", err)
+	}
+
+	for _, testCase := range []struct {
+		name                string
+		handler             nuclio.Handler
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name: "string",
+			handler: func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+				context.Logger.InfoWith("Greeting", "worker", context.WorkerID)
+				return "hello " + string(event.GetBody()), nil
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/plain",
+			expectedBody:        "hello world",
+		},
+		{
+			name: "byteSlice",
+			handler: func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+				return event.GetBody(), nil
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/plain",
+			expectedBody:        "world",
+		},
+		{
+			name: "map",
+			handler: func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+				return map[string]interface{}{"function": context.FunctionName}, nil
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        `{"function":"greeter"}`,
+		},
+		{
+			name: "response",
+			handler: func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+				return nuclio.Response{
+					StatusCode:  http.StatusCreated,
+					ContentType: "text/csv",
+					Body:        []byte("a,b"),
+				}, nil
+			},
+			expectedStatusCode:  http.StatusCreated,
+			expectedContentType: "text/csv",
+			expectedBody:        "a,b",
+		},
+		{
+			name: "stream",
+			handler: func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+				responseStream := nuclio.NewResponseStream("text/plain", nil, http.StatusOK)
+				go func() {
+					defer responseStream.StopStreaming()
+
+					responseStream.SendChunk([]byte("chunk1,")) // nolint: errcheck
+					responseStream.SendChunk([]byte("chunk2"))  // nolint: errcheck
+				}()
+
+				return responseStream, nil
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/plain",
+			expectedBody:        "chunk1,chunk2",
+		},
+		{
+			name: "errorWithStatusCode",
+			handler: func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+				return nil, nuclio.NewErrNotFound("no such greeting")
+			},
+			expectedStatusCode:  http.StatusNotFound,
+			expectedContentType: "text/plain",
+			expectedBody:        "no such greeting",
+		},
+		{
+			name: "panic",
+			handler: func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+				panic("oops")
+			},
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedContentType: "text/plain",
+			expectedBody:        "Handler panicked: oops",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := harness.Invoke(testCase.handler, &nuclio.MemoryEvent{Body: []byte("world")})
+
+			if result.StatusCode != testCase.expectedStatusCode {
+				t.Fatalf("Bad status code: %d != %d", result.StatusCode, testCase.expectedStatusCode)
+			}
+
+			if result.ContentType != testCase.expectedContentType {
+				t.Fatalf("Bad content type: %q != %q", result.ContentType, testCase.expectedContentType)
+			}
+
+			if result.String() != testCase.expectedBody {
+				t.Fatalf("Bad body: %q != %q", result.String(), testCase.expectedBody)
+			}
+		})
+	}
+
+	infoRecords := harness.Logger.RecordsWithLevel(logger.LevelInfo)
+	if len(infoRecords) != 1 || infoRecords[0].Message != "Greeting" || infoRecords[0].Vars[1] != 3 {
+		t.Fatalf("Bad log records: %+v", infoRecords)
+	}
+}
+
+func TestInvokeSetsTriggerInfo(t *testing.T) {
+	harness, err := NewHarness(&ContextOptions{TriggerKind: "kafka-cluster", TriggerName: "orders"})
+	if err != nil {
+		t.Fatalf("Failed to create harness: %s", err)
+	}
+
+	result := harness.Invoke(func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+		return event.GetTriggerInfo().GetKind() + "/" + event.GetTriggerInfo().GetName(), nil
+	}, &nuclio.MemoryEvent{})
+
+	if result.String() != "kafka-cluster/orders" {
+		t.Fatalf("Bad trigger info: %s", result.String())
+	}
+}
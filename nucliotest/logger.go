@@ -0,0 +1,204 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nucliotest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/nuclio/logger"
+)
+
+// LogRecord is a single entry captured by Logger
+type LogRecord struct {
+
+	// Name is the name of the (child) logger which emitted the record
+	Name string
+
+	// Level is the verbosity of the record
+	Level logger.Level
+
+	// Message is the formatted message for unstructured records, or the message as is for
+	// structured records
+	Message string
+
+	// Vars holds the key / value pairs of structured records
+	Vars []interface{}
+}
+
+// Logger is a logger.Logger capturing all records in memory. Child loggers share their parent's records
+type Logger struct {
+	name    string
+	lock    *sync.Mutex
+	records *[]LogRecord
+}
+
+// NewLogger creates a new capturing logger
+func NewLogger(name string) *Logger {
+	return &Logger{
+		name:    name,
+		lock:    &sync.Mutex{},
+		records: &[]LogRecord{},
+	}
+}
+
+// Records returns a copy of all captured records
+func (l *Logger) Records() []LogRecord {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return append([]LogRecord(nil), *l.records...)
+}
+
+// RecordsWithLevel returns a copy of all captured records of the given level
+func (l *Logger) RecordsWithLevel(level logger.Level) []LogRecord {
+	var records []LogRecord
+
+	for _, record := range l.Records() {
+		if record.Level == level {
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
+// Reset discards all captured records
+func (l *Logger) Reset() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	*l.records = nil
+}
+
+// Error emits an unstructured error log
+func (l *Logger) Error(format interface{}, vars ...interface{}) {
+	l.emitUnstructured(logger.LevelError, format, vars)
+}
+
+// Warn emits an unstructured warning log
+func (l *Logger) Warn(format interface{}, vars ...interface{}) {
+	l.emitUnstructured(logger.LevelWarn, format, vars)
+}
+
+// Info emits an unstructured informational log
+func (l *Logger) Info(format interface{}, vars ...interface{}) {
+	l.emitUnstructured(logger.LevelInfo, format, vars)
+}
+
+// Debug emits an unstructured debug log
+func (l *Logger) Debug(format interface{}, vars ...interface{}) {
+	l.emitUnstructured(logger.LevelDebug, format, vars)
+}
+
+// ErrorCtx emits an unstructured error log with context
+func (l *Logger) ErrorCtx(ctx context.Context, format interface{}, vars ...interface{}) {
+	l.emitUnstructured(logger.LevelError, format, vars)
+}
+
+// WarnCtx emits an unstructured warning log with context
+func (l *Logger) WarnCtx(ctx context.Context, format interface{}, vars ...interface{}) {
+	l.emitUnstructured(logger.LevelWarn, format, vars)
+}
+
+// InfoCtx emits an unstructured informational log with context
+func (l *Logger) InfoCtx(ctx context.Context, format interface{}, vars ...interface{}) {
+	l.emitUnstructured(logger.LevelInfo, format, vars)
+}
+
+// DebugCtx emits an unstructured debug log with context
+func (l *Logger) DebugCtx(ctx context.Context, format interface{}, vars ...interface{}) {
+	l.emitUnstructured(logger.LevelDebug, format, vars)
+}
+
+// ErrorWith emits a structured error log
+func (l *Logger) ErrorWith(format interface{}, vars ...interface{}) {
+	l.emitStructured(logger.LevelError, format, vars)
+}
+
+// WarnWith emits a structured warning log
+func (l *Logger) WarnWith(format interface{}, vars ...interface{}) {
+	l.emitStructured(logger.LevelWarn, format, vars)
+}
+
+// InfoWith emits a structured info log
+func (l *Logger) InfoWith(format interface{}, vars ...interface{}) {
+	l.emitStructured(logger.LevelInfo, format, vars)
+}
+
+// DebugWith emits a structured debug log
+func (l *Logger) DebugWith(format interface{}, vars ...interface{}) {
+	l.emitStructured(logger.LevelDebug, format, vars)
+}
+
+// ErrorWithCtx emits a structured error log with context
+func (l *Logger) ErrorWithCtx(ctx context.Context, format interface{}, vars ...interface{}) {
+	l.emitStructured(logger.LevelError, format, vars)
+}
+
+// WarnWithCtx emits a structured warning log with context
+func (l *Logger) WarnWithCtx(ctx context.Context, format interface{}, vars ...interface{}) {
+	l.emitStructured(logger.LevelWarn, format, vars)
+}
+
+// InfoWithCtx emits a structured info log with context
+func (l *Logger) InfoWithCtx(ctx context.Context, format interface{}, vars ...interface{}) {
+	l.emitStructured(logger.LevelInfo, format, vars)
+}
+
+// DebugWithCtx emits a structured debug log with context
+func (l *Logger) DebugWithCtx(ctx context.Context, format interface{}, vars ...interface{}) {
+	l.emitStructured(logger.LevelDebug, format, vars)
+}
+
+// Flush does nothing, as records are captured immediately
+func (l *Logger) Flush() {}
+
+// GetChild returns a child logger sharing the records of this logger
+func (l *Logger) GetChild(name string) logger.Logger {
+	childName := name
+	if l.name != "" {
+		childName = l.name + "." + name
+	}
+
+	return &Logger{
+		name:    childName,
+		lock:    l.lock,
+		records: l.records,
+	}
+}
+
+func (l *Logger) emitUnstructured(level logger.Level, format interface{}, vars []interface{}) {
+	message := fmt.Sprint(format)
+	if formatString, ok := format.(string); ok && len(vars) > 0 {
+		message = fmt.Sprintf(formatString, vars...)
+	}
+
+	l.emit(LogRecord{Name: l.name, Level: level, Message: message})
+}
+
+func (l *Logger) emitStructured(level logger.Level, format interface{}, vars []interface{}) {
+	l.emit(LogRecord{Name: l.name, Level: level, Message: fmt.Sprint(format), Vars: vars})
+}
+
+func (l *Logger) emit(record LogRecord) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	*l.records = append(*l.records, record)
+}
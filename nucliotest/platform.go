@@ -0,0 +1,231 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nucliotest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nuclio/nuclio-sdk-go"
+
+	"github.com/nuclio/logger"
+	"github.com/valyala/fasthttp"
+)
+
+// ErrNoHandler is returned when calling a function which has neither a registered handler nor
+// scripted calls left
+var ErrNoHandler = errors.New("No handler registered for function")
+
+// Call is a function call recorded by FakePlatform
+type Call struct {
+	FunctionName string
+	Event        *nuclio.MemoryEvent
+}
+
+type scriptedCall struct {
+	response nuclio.Response
+	err      error
+}
+
+// FakePlatform provides a nuclio.Platform whose function calls are served in process: by scripted
+// responses or errors, or by registered handlers. All calls are recorded
+type FakePlatform struct {
+
+	// Platform is the platform to set in the context of handlers under test
+	Platform *nuclio.Platform
+
+	logger        logger.Logger
+	lock          sync.Mutex
+	handlers      map[string]nuclio.Handler
+	scriptedCalls map[string][]scriptedCall
+	calls         []Call
+}
+
+// NewFakePlatform creates a fake platform. The configuration (which may be nil) applies as usual,
+// except for its function resolver and transport which are replaced by the fake
+func NewFakePlatform(parentLogger logger.Logger, configuration *nuclio.PlatformConfiguration) (*FakePlatform, error) {
+	var err error
+
+	fakePlatform := &FakePlatform{
+		logger:        parentLogger,
+		handlers:      map[string]nuclio.Handler{},
+		scriptedCalls: map[string][]scriptedCall{},
+	}
+
+	platformConfiguration := nuclio.NewDefaultPlatformConfiguration()
+	if configuration != nil {
+		*platformConfiguration = *configuration
+	}
+
+	// the function name is used as its address, so that the transport can tell which was called
+	platformConfiguration.FunctionResolver = nuclio.FunctionResolverFunc(func(functionName string) (string, error) {
+		return functionName, nil
+	})
+
+	platformConfiguration.Transport = fakePlatform
+
+	fakePlatform.Platform, err = nuclio.NewPlatformWithConfiguration(parentLogger,
+		"local",
+		"nuclio",
+		platformConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create platform: %w", err)
+	}
+
+	return fakePlatform, nil
+}
+
+// RegisterHandler serves calls to the function with the handler, once any scripted calls are used up
+func (fp *FakePlatform) RegisterHandler(functionName string, handler nuclio.Handler) {
+	fp.lock.Lock()
+	defer fp.lock.Unlock()
+
+	fp.handlers[functionName] = handler
+}
+
+// ScriptResponse queues a response for the next unscripted call to the function
+func (fp *FakePlatform) ScriptResponse(functionName string, response nuclio.Response) {
+	fp.lock.Lock()
+	defer fp.lock.Unlock()
+
+	fp.scriptedCalls[functionName] = append(fp.scriptedCalls[functionName], scriptedCall{response: response})
+}
+
+// ScriptError queues a transport error (e.g. a timeout) for the next unscripted call to the function
+func (fp *FakePlatform) ScriptError(functionName string, err error) {
+	fp.lock.Lock()
+	defer fp.lock.Unlock()
+
+	fp.scriptedCalls[functionName] = append(fp.scriptedCalls[functionName], scriptedCall{err: err})
+}
+
+// Calls returns all recorded calls, in order
+func (fp *FakePlatform) Calls() []Call {
+	fp.lock.Lock()
+	defer fp.lock.Unlock()
+
+	return append([]Call(nil), fp.calls...)
+}
+
+// CallsTo returns the recorded calls to the function, in order
+func (fp *FakePlatform) CallsTo(functionName string) []Call {
+	var calls []Call
+
+	for _, call := range fp.Calls() {
+		if call.FunctionName == functionName {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Reset discards all handlers, scripted calls and recorded calls
+func (fp *FakePlatform) Reset() {
+	fp.lock.Lock()
+	defer fp.lock.Unlock()
+
+	fp.handlers = map[string]nuclio.Handler{}
+	fp.scriptedCalls = map[string][]scriptedCall{}
+	fp.calls = nil
+}
+
+// Do serves a request sent by the platform
+func (fp *FakePlatform) Do(request *fasthttp.Request, response *fasthttp.Response, deadline time.Time) error {
+	functionName := string(request.Host())
+	event := requestToEvent(request)
+
+	fp.lock.Lock()
+	fp.calls = append(fp.calls, Call{FunctionName: functionName, Event: event})
+
+	handler := fp.handlers[functionName]
+	scriptedCalls := fp.scriptedCalls[functionName]
+
+	var nextScriptedCall *scriptedCall
+	if len(scriptedCalls) > 0 {
+		nextScriptedCall = &scriptedCalls[0]
+		fp.scriptedCalls[functionName] = scriptedCalls[1:]
+	}
+	fp.lock.Unlock()
+
+	switch {
+	case nextScriptedCall != nil && nextScriptedCall.err != nil:
+		return nextScriptedCall.err
+
+	case nextScriptedCall != nil:
+		writeResult(NormalizeResult(nextScriptedCall.response, nil), response)
+
+	case handler != nil:
+		writeResult(invokeHandler(fp.newContext(functionName), handler, event), response)
+
+	default:
+		return fmt.Errorf("%w: %s", ErrNoHandler, functionName)
+	}
+
+	return nil
+}
+
+func (fp *FakePlatform) newContext(functionName string) *nuclio.Context {
+	return &nuclio.Context{
+		Logger:              fp.logger.GetChild(functionName),
+		DataBinding:         map[string]nuclio.DataBinding{},
+		Platform:            fp.Platform,
+		FunctionName:        functionName,
+		TriggerKind:         "http",
+		TriggerName:         "default-http",
+		WorkerAllocatorName: "test-allocator",
+	}
+}
+
+func requestToEvent(request *fasthttp.Request) *nuclio.MemoryEvent {
+	event := nuclio.NewMemoryEvent().
+		WithTrigger("sync", "http", "default-http").
+		WithMethod(string(request.Header.Method())).
+		WithPath(string(request.URI().Path())).
+		WithURL(request.URI().String()).
+		WithBody(string(request.Header.ContentType()), append([]byte(nil), request.Body()...))
+
+	request.Header.VisitAll(func(key, value []byte) {
+		event.Headers.Add(string(key), string(value))
+	})
+
+	return event
+}
+
+func writeResult(result *Result, response *fasthttp.Response) {
+	response.SetStatusCode(result.StatusCode)
+
+	for headerKey := range result.Headers {
+		response.Header.Del(headerKey)
+		for _, headerValue := range result.Headers.Values(headerKey) {
+			response.Header.Add(headerKey, headerValue)
+		}
+	}
+
+	if result.ContentType != "" {
+		response.Header.SetContentType(result.ContentType)
+	}
+
+	if result.Err != nil && result.StatusCode == http.StatusOK {
+		response.SetStatusCode(http.StatusInternalServerError)
+	}
+
+	response.SetBody(result.Body)
+}
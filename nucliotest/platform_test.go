@@ -0,0 +1,86 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nucliotest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/nuclio/nuclio-sdk-go"
+)
+
+func TestFakePlatform(t *testing.T) {
+	harness, err := NewHarness(&ContextOptions{
+		PlatformConfiguration: &nuclio.PlatformConfiguration{
+			RetryPolicy: &nuclio.RetryPolicy{
+				MaxAttempts:          2,
+				RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create harness: %s", err)
+	}
+
+	harness.FakePlatform.ScriptResponse("upper", nuclio.Response{StatusCode: http.StatusServiceUnavailable})
+	harness.FakePlatform.RegisterHandler("upper", func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+		return "UPPER " + string(event.GetBody()), nil
+	})
+
+	result := harness.Invoke(func(context *nuclio.Context, event nuclio.Event) (interface{}, error) {
+		response, err := context.Platform.CallFunction("upper", &nuclio.MemoryEvent{
+			Method: "PUT",
+			Path:   "/convert",
+			Body:   event.GetBody(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return response, nil
+	}, &nuclio.MemoryEvent{Body: []byte("hello")})
+
+	if result.StatusCode != http.StatusOK || result.String() != "UPPER hello" {
+		t.Fatalf("Bad result: %d %s (%v)", result.StatusCode, result.String(), result.Err)
+	}
+
+	calls := harness.FakePlatform.CallsTo("upper")
+	if len(calls) != 2 {
+		t.Fatalf("Expected a retried call, got %d calls", len(calls))
+	}
+
+	if calls[1].Event.GetMethod() != "PUT" || calls[1].Event.GetPath() != "/convert" {
+		t.Fatalf("Bad recorded event: %s %s", calls[1].Event.GetMethod(), calls[1].Event.GetPath())
+	}
+}
+
+func TestFakePlatformScriptedError(t *testing.T) {
+	harness, err := NewHarness(nil)
+	if err != nil {
+		t.Fatalf("Failed to create harness: %s", err)
+	}
+
+	harness.FakePlatform.ScriptError("flaky", nuclio.ErrUnsupported)
+
+	if _, err := harness.Context.Platform.CallFunction("flaky", &nuclio.MemoryEvent{}); err != nuclio.ErrUnsupported {
+		t.Fatalf("Expected scripted error, got: %v", err)
+	}
+
+	if _, err := harness.Context.Platform.CallFunction("flaky", &nuclio.MemoryEvent{}); err == nil {
+		t.Fatalf("Expected call to function without handler to fail")
+	}
+}
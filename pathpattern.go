@@ -0,0 +1,149 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"net/url"
+	"strings"
+)
+
+type pathSegmentKind int
+
+const (
+	pathSegmentLiteral pathSegmentKind = iota
+	pathSegmentParam
+	pathSegmentWildcard
+	pathSegmentRest
+)
+
+type pathSegment struct {
+	kind  pathSegmentKind
+	value string
+}
+
+// pathPattern matches paths like /users/{id}/files/{path...}, where {name} captures a single segment,
+// * matches any single segment and a trailing {name...} captures the rest of the path
+type pathPattern struct {
+	pattern  string
+	segments []pathSegment
+}
+
+func newPathPattern(pattern string) *pathPattern {
+	pp := &pathPattern{
+		pattern: pattern,
+	}
+
+	parts := splitPath(pattern)
+	for partIndex, part := range parts {
+		switch {
+		case part == "*":
+			pp.segments = append(pp.segments, pathSegment{kind: pathSegmentWildcard})
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "...}") && partIndex == len(parts)-1:
+			pp.segments = append(pp.segments, pathSegment{
+				kind:  pathSegmentRest,
+				value: strings.TrimSuffix(part[1:], "...}"),
+			})
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+
+			// a rest parameter which isn't last captures a single segment
+			pp.segments = append(pp.segments, pathSegment{
+				kind:  pathSegmentParam,
+				value: strings.TrimSuffix(strings.TrimSuffix(part[1:], "}"), "..."),
+			})
+		default:
+			pp.segments = append(pp.segments, pathSegment{kind: pathSegmentLiteral, value: part})
+		}
+	}
+
+	return pp
+}
+
+// match returns the parameters captured from the path, and whether the path matches
+func (pp *pathPattern) match(path string) (map[string]string, bool) {
+	parts := splitPath(path)
+	params := map[string]string{}
+
+	for segmentIndex, segment := range pp.segments {
+		if segment.kind == pathSegmentRest {
+			params[segment.value] = unescapePathSegment(strings.Join(parts[segmentIndex:], "/"))
+			return params, true
+		}
+
+		if segmentIndex >= len(parts) {
+			return nil, false
+		}
+
+		part := parts[segmentIndex]
+
+		switch segment.kind {
+		case pathSegmentLiteral:
+			if part != segment.value {
+				return nil, false
+			}
+		case pathSegmentParam:
+			if part == "" {
+				return nil, false
+			}
+
+			params[segment.value] = unescapePathSegment(part)
+		}
+	}
+
+	if len(parts) != len(pp.segments) {
+		return nil, false
+	}
+
+	return params, true
+}
+
+// isMoreSpecificThan checks if the pattern is more specific than another pattern matching the same
+// path, comparing segment by segment - literals are more specific than parameters, which are more
+// specific than wildcards and rest parameters
+func (pp *pathPattern) isMoreSpecificThan(other *pathPattern) bool {
+	for segmentIndex := 0; segmentIndex < len(pp.segments) && segmentIndex < len(other.segments); segmentIndex++ {
+		kind, otherKind := pp.segments[segmentIndex].kind, other.segments[segmentIndex].kind
+		if kind != otherKind {
+			return kind < otherKind
+		}
+	}
+
+	// a rest parameter may match no segments, so the longer pattern is more specific
+	return len(pp.segments) > len(other.segments)
+}
+
+// splitPath splits a path to its segments, ignoring the query string and leading or trailing slashes
+func splitPath(path string) []string {
+	if queryIndex := strings.IndexByte(path, '?'); queryIndex != -1 {
+		path = path[:queryIndex]
+	}
+
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+
+	return strings.Split(path, "/")
+}
+
+func unescapePathSegment(segment string) string {
+	unescaped, err := url.PathUnescape(segment)
+	if err != nil {
+		return segment
+	}
+
+	return unescaped
+}
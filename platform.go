@@ -14,88 +14,438 @@ limitations under the License.
 package nuclio
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
-	"strconv"
+	"io"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/nuclio/logger"
 	"github.com/valyala/fasthttp"
 )
 
+// PlatformConfiguration holds the defaults applied by the platform when calling other functions
+type PlatformConfiguration struct {
+
+	// CallTimeout is the maximum duration of a single request to a function. If the context passed
+	// to the call carries an earlier deadline, the context deadline is used. Zero means
+	// DefaultCallTimeout. Calls are always bounded, so that a hung function can't block the caller
+	// (or hold a connection) forever. For streamed calls this includes reading the response body
+	CallTimeout time.Duration
+
+	// ConnectTimeout is the maximum duration of establishing a connection to a function
+	ConnectTimeout time.Duration
+
+	// ReadTimeout is the maximum duration of reading a full response from a function. Zero means no limit
+	ReadTimeout time.Duration
+
+	// WriteTimeout is the maximum duration of writing a full request to a function. Zero means no limit
+	WriteTimeout time.Duration
+
+	// RetryPolicy controls retrying failed calls. If nil, every call is attempted exactly once
+	RetryPolicy *RetryPolicy
+
+	// FunctionResolver resolves the addresses of called functions. If nil, the default resolver of
+	// the platform kind is used
+	FunctionResolver FunctionResolver
+
+	// TracePropagation selects the formats in which trace contexts are propagated to called
+	// functions. Zero means W3C only
+	TracePropagation TracePropagation
+
+	// Transport sends requests to functions. If nil, requests are sent over the network, applying
+	// the timeouts above
+	Transport Transport
+}
+
+// DefaultCallTimeout is the maximum duration of a single request to a function, unless configured
+// otherwise through PlatformConfiguration.CallTimeout
+const DefaultCallTimeout = time.Minute
+
+// NewDefaultPlatformConfiguration returns the configuration used by NewPlatform
+func NewDefaultPlatformConfiguration() *PlatformConfiguration {
+	return &PlatformConfiguration{
+		CallTimeout:    DefaultCallTimeout,
+		ConnectTimeout: 10 * time.Second,
+	}
+}
+
 type Platform struct {
-	client    fasthttp.Client
-	logger    logger.Logger
-	kind      string
-	namespace string
+	transport        Transport
+	logger           logger.Logger
+	kind             string
+	namespace        string
+	configuration    PlatformConfiguration
+	functionResolver FunctionResolver
+	traceContext     *TraceContext
+}
+
+type callResult struct {
+	response Response
+	err      error
 }
 
 func NewPlatform(parentLogger logger.Logger, kind string, namespace string) (*Platform, error) {
-	return &Platform{
-		client:    fasthttp.Client{},
-		logger:    parentLogger.GetChild("platform"),
-		kind:      kind,
-		namespace: namespace,
-	}, nil
+	return NewPlatformWithConfiguration(parentLogger, kind, namespace, NewDefaultPlatformConfiguration())
 }
 
+func NewPlatformWithConfiguration(parentLogger logger.Logger,
+	kind string,
+	namespace string,
+	configuration *PlatformConfiguration) (*Platform, error) {
+	if configuration == nil {
+		configuration = NewDefaultPlatformConfiguration()
+	}
+
+	newPlatform := &Platform{
+		logger:        parentLogger.GetChild("platform"),
+		kind:          kind,
+		namespace:     namespace,
+		configuration: *configuration,
+	}
+
+	newPlatform.functionResolver = configuration.FunctionResolver
+	if newPlatform.functionResolver == nil {
+		newPlatform.functionResolver = NewDefaultFunctionResolver(kind, namespace)
+	}
+
+	newPlatform.transport = configuration.Transport
+	if newPlatform.transport == nil {
+		newPlatform.transport = NewClientTransport(configuration)
+	}
+
+	return newPlatform, nil
+}
+
+// WithTraceContext returns a copy of the platform whose calls become children of the trace context,
+// unless the context passed to the call carries a trace context of its own
+func (p *Platform) WithTraceContext(traceContext *TraceContext) *Platform {
+	platformCopy := *p
+	platformCopy.traceContext = traceContext
+
+	return &platformCopy
+}
+
+// CallFunction calls a function by name, waiting at most the configured call timeout
 func (p *Platform) CallFunction(functionName string, event Event) (Response, error) {
+	return p.CallFunctionWithContext(context.Background(), functionName, event)
+}
+
+// CallFunctionWithContext calls a function by name, giving up when the context is done. A call that
+// times out returns an error wrapping ErrGatewayTimeout. Failed attempts are retried according to
+// the configured retry policy
+func (p *Platform) CallFunctionWithContext(ctx context.Context, functionName string, event Event) (Response, error) {
+	functionAddress, err := p.resolveFunctionAddress(functionName)
+	if err != nil {
+		return Response{}, err
+	}
+
+	result, err := p.callWithRetries(ctx, functionName, event, func() (ProcessingResult, error) {
+		response, err := p.callFunctionOnce(ctx, functionName, functionAddress, event)
+		return &response, err
+	}, nil)
+
+	return *result.(*Response), err
+}
+
+// CallFunctionStream calls a function by name, returning a ResponseStream whose body is read directly
+// from the connection to the function rather than buffered in memory. The caller must close the body
+// (an io.ReadCloser) once done with it. Reading the body fails once the context is done, and once the
+// call timeout passes
+func (p *Platform) CallFunctionStream(ctx context.Context, functionName string, event Event) (ProcessingResult, error) {
+	functionAddress, err := p.resolveFunctionAddress(functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := p.callWithRetries(ctx, functionName, event, func() (ProcessingResult, error) {
+		return p.callFunctionStreamOnce(ctx, functionName, functionAddress, event)
+	}, func(result ProcessingResult) {
+		_ = result.GetBody().(io.Closer).Close()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (p *Platform) resolveFunctionAddress(functionName string) (string, error) {
+	functionAddress, err := p.functionResolver.ResolveFunctionAddress(functionName)
+	if err != nil {
+		return "", fmt.Errorf("Failed to resolve address of function %s: %w", functionName, err)
+	}
+
+	return functionAddress, nil
+}
+
+// callWithRetries performs attempts until one succeeds with a status code that isn't retryable, fails
+// with an error that isn't retryable, the retry policy is exhausted or the context is done. Results
+// that are about to be retried are passed to discard, if given
+func (p *Platform) callWithRetries(ctx context.Context,
+	functionName string,
+	event Event,
+	attempt func() (ProcessingResult, error),
+	discard func(ProcessingResult)) (ProcessingResult, error) {
+	retryPolicy := p.configuration.RetryPolicy
+	maxAttempts := retryPolicy.getMaxAttempts()
+
+	for attemptIndex := 1; ; attemptIndex++ {
+		result, err := attempt()
+
+		// the context is done - there's no point in retrying
+		if err != nil && ctx.Err() != nil {
+			return result, err
+		}
+
+		if err == nil && !retryPolicy.isRetryableResult(event.GetMethod(), result) {
+			return result, nil
+		}
+
+		if attemptIndex >= maxAttempts || (err != nil && !retryPolicy.isRetryableError(event.GetMethod(), err)) {
+			return result, err
+		}
+
+		retryAfter, hasRetryAfter := time.Duration(0), false
+		if err == nil && !retryPolicy.IgnoreRetryAfter {
+			retryAfter, hasRetryAfter = getRetryAfter(result.GetHeaders())
+
+			// the function asked to wait longer than we're willing to - return its response instead
+			if hasRetryAfter && retryAfter > retryPolicy.getMaxRetryAfter() {
+				return result, nil
+			}
+		}
+
+		if !hasRetryAfter {
+			retryAfter = retryPolicy.getBackoff(attemptIndex)
+		}
+
+		// don't wait past the deadline - return the last result instead
+		if deadline, hasDeadline := ctx.Deadline(); hasDeadline && time.Now().Add(retryAfter).After(deadline) {
+			return result, err
+		}
+
+		statusCode := 0
+		if err == nil {
+			statusCode = result.GetStatusCode()
+
+			if discard != nil {
+				discard(result)
+			}
+		}
+
+		p.logger.DebugWith("Retrying function call",
+			"functionName", functionName,
+			"attempt", attemptIndex,
+			"statusCode", statusCode,
+			"err", err,
+			"retryAfter", retryAfter)
+
+		timer := time.NewTimer(retryAfter)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return result, p.wrapCallError(functionName, ctx.Err())
+		}
+	}
+}
+
+func (p *Platform) callFunctionOnce(ctx context.Context,
+	functionName string,
+	functionAddress string,
+	event Event) (Response, error) {
 	var emptyResponse Response
 
-	request := fasthttp.AcquireRequest()
-	defer fasthttp.ReleaseRequest(request)
+	if err := ctx.Err(); err != nil {
+		return emptyResponse, p.wrapCallError(functionName, err)
+	}
+
+	deadline := p.getCallDeadline(ctx)
+
+	// the request and response are owned by the goroutine, since it may outlive this call if the
+	// context is cancelled while the request is in flight. The deadline bounds how long it does
+	resultChan := make(chan callResult, 1)
+	go func() {
+		request := fasthttp.AcquireRequest()
+		defer fasthttp.ReleaseRequest(request)
+
+		request = p.enrichRequest(ctx, request, functionAddress, event)
+
+		response := fasthttp.AcquireResponse()
+		defer fasthttp.ReleaseResponse(response)
+
+		if err := p.transport.Do(request, response, deadline); err != nil {
+			resultChan <- callResult{err: err}
+			return
+		}
+
+		resultChan <- callResult{response: p.wrapResponse(response)}
+	}()
+
+	select {
+	case result := <-resultChan:
+		if result.err != nil {
+			return emptyResponse, p.wrapCallError(functionName, result.err)
+		}
+
+		return result.response, nil
+
+	case <-ctx.Done():
+		return emptyResponse, p.wrapCallError(functionName, ctx.Err())
+	}
+}
 
-	request = p.enrichRequest(request, functionName, event)
+func (p *Platform) callFunctionStreamOnce(ctx context.Context,
+	functionName string,
+	functionAddress string,
+	event Event) (*ResponseStream, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, p.wrapCallError(functionName, err)
+	}
+
+	// the deadline bounds both waiting for the response headers and reading the body, so that neither
+	// the request nor an abandoned body read can block forever
+	deadline := p.getCallDeadline(ctx)
 
 	response := fasthttp.AcquireResponse()
-	defer fasthttp.ReleaseResponse(response)
+	response.StreamBody = true
+
+	errChan := make(chan error, 1)
+	go func() {
+		request := fasthttp.AcquireRequest()
+		defer fasthttp.ReleaseRequest(request)
+
+		p.enrichRequest(ctx, request, functionAddress, event)
+
+		// bodies may be abandoned before they are read in full, so their connections are never reused
+		request.SetConnectionClose()
+
+		errChan <- p.transport.Do(request, response, deadline)
+	}()
+
+	select {
+	case err := <-errChan:
+		if err != nil {
+			fasthttp.ReleaseResponse(response)
+			return nil, p.wrapCallError(functionName, err)
+		}
+
+	case <-ctx.Done():
+
+		// the response is still in use - release it once the request completes, at the latest when
+		// the deadline passes
+		go func() {
+			if err := <-errChan; err == nil {
+				_ = response.CloseBodyStream()
+			}
+
+			fasthttp.ReleaseResponse(response)
+		}()
+
+		return nil, p.wrapCallError(functionName, ctx.Err())
+	}
 
-	if err := p.client.Do(request, response); err != nil {
-		return emptyResponse, err
+	bodyReader := &responseBodyReader{
+		ctx:      ctx,
+		response: response,
+		reader:   response.BodyStream(),
 	}
 
-	return p.wrapResponse(response), nil
+	// the body may have been read in full, in which case there's no stream
+	if bodyReader.reader == nil {
+		bodyReader.reader = bytes.NewReader(response.Body())
+	}
+
+	contentType, headers := p.wrapResponseHeaders(response)
+
+	return NewCustomResponseStream(contentType, headers, response.StatusCode(), bodyReader, nil), nil
 }
 
-func (p *Platform) getFunctionHost(name string) string {
-	var functionHost string
+// getCallDeadline returns the deadline of a single request - the earlier of the context deadline
+// and the call timeout
+func (p *Platform) getCallDeadline(ctx context.Context) time.Time {
+	callTimeout := p.configuration.CallTimeout
+	if callTimeout <= 0 {
+		callTimeout = DefaultCallTimeout
+	}
 
-	if p.kind == "local" {
-		functionHost = fmt.Sprintf("nuclio-%s-%s", p.namespace, name)
-	} else {
-		functionHost = fmt.Sprintf("nuclio-%s", name)
+	callDeadline := time.Now().Add(callTimeout)
+	if deadline, hasDeadline := ctx.Deadline(); hasDeadline && deadline.Before(callDeadline) {
+		return deadline
 	}
 
-	return fmt.Sprintf("%s:8080", functionHost)
+	return callDeadline
 }
 
-func (p *Platform) enrichRequest(request *fasthttp.Request, functionName string, event Event) *fasthttp.Request {
-	request.URI().SetScheme("http")
-	request.URI().SetHost(p.getFunctionHost(functionName))
-	request.URI().SetPath(event.GetPath())
-	request.SetBody(event.GetBody())
-	request.Header.SetContentType(event.GetContentType())
-	request.Header.SetMethod(event.GetMethod())
+func (p *Platform) wrapCallError(functionName string, err error) error {
+	if isTimeoutError(err) {
+		return WrapErrGatewayTimeout(fmt.Errorf("Timed out calling function %s: %w", functionName, err))
+	}
 
-	for headerKey, headerValue := range event.GetHeaders() {
-		switch typedHeaderValue := headerValue.(type) {
-		case string:
-			request.Header.Set(headerKey, typedHeaderValue)
+	return err
+}
 
-		case int:
-			request.Header.Set(headerKey, strconv.Itoa(typedHeaderValue))
+func isTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, fasthttp.ErrDialTimeout) {
+		return true
+	}
 
-		case bool:
-			request.Header.Set(headerKey, strconv.FormatBool(typedHeaderValue))
+	var timeoutError interface{ Timeout() bool }
+	return errors.As(err, &timeoutError) && timeoutError.Timeout()
+}
 
-		case []byte:
-			request.Header.Set(headerKey, string(typedHeaderValue))
+func (p *Platform) enrichRequest(ctx context.Context,
+	request *fasthttp.Request,
+	functionAddress string,
+	event Event) *fasthttp.Request {
+	scheme, host := "http", functionAddress
+	if schemeSeparatorIndex := strings.Index(functionAddress, "://"); schemeSeparatorIndex != -1 {
+		scheme, host = functionAddress[:schemeSeparatorIndex], functionAddress[schemeSeparatorIndex+3:]
+	}
 
-		default:
+	request.URI().SetScheme(scheme)
+	request.URI().SetHost(host)
+	request.URI().SetPath(event.GetPath())
+	request.SetBody(event.GetBody())
+	request.Header.SetContentType(event.GetContentType())
+	request.Header.SetMethod(event.GetMethod())
+
+	for headerKey, headerValue := range Headers(event.GetHeaders()) {
+		headerValues, supported := formatHeaderValues(headerValue)
+		if !supported {
 			p.logger.WarnWith("Header value is of an unsupported type. Ignoring it",
 				"headerKey",
 				headerKey,
 				"headerValue",
 				headerValue)
+
+			continue
 		}
+
+		request.Header.Del(headerKey)
+		for _, value := range headerValues {
+			request.Header.Add(headerKey, value)
+		}
+	}
+
+	// the called function handles a child span of the trace carried by the context, the trace the
+	// platform is bound to or, failing that, the trace carried by the event itself
+	traceContext := TraceContextFromContext(ctx)
+	if traceContext == nil {
+		traceContext = p.traceContext
+	}
+
+	if traceContext == nil {
+		traceContext = ExtractTraceContext(event)
+	}
+
+	if traceContext != nil {
+		injectTraceContext(traceContext.NewChild(), p.configuration.TracePropagation, request.Header.Set)
 	}
 
 	return request
@@ -103,20 +453,96 @@ func (p *Platform) enrichRequest(request *fasthttp.Request, functionName string,
 
 func (p *Platform) wrapResponse(response *fasthttp.Response) Response {
 	result := Response{}
-	if len(response.Header.ContentType()) == 0 {
-		result.ContentType = "text/plain"
-	} else {
-		result.ContentType = string(response.Header.ContentType())
-	}
 
+	result.ContentType, result.Headers = p.wrapResponseHeaders(response)
 	result.StatusCode = response.StatusCode()
+	result.Body = append(result.Body, response.Body()...)
 
-	result.Headers = make(map[string]interface{}, response.Header.Len())
+	return result
+}
+
+func (p *Platform) wrapResponseHeaders(response *fasthttp.Response) (string, Headers) {
+	contentType := "text/plain"
+	if len(response.Header.ContentType()) != 0 {
+		contentType = string(response.Header.ContentType())
+	}
+
+	// headers which repeat (e.g. Set-Cookie) are kept as a []string
+	headers := make(Headers, response.Header.Len())
 	response.Header.VisitAll(func(key, value []byte) {
-		result.Headers[string(key)] = string(value)
+		headers.Add(string(key), string(value))
 	})
 
-	result.Body = append(result.Body, response.Body()...)
+	return contentType, headers
+}
 
-	return result
+// responseBodyReader reads a streamed response body, releasing the response once closed. Reads
+// return once the context is done, even if the connection is stalled
+type responseBodyReader struct {
+	ctx         context.Context
+	response    *fasthttp.Response
+	reader      io.Reader
+	readBuffer  []byte
+	pendingRead chan bodyReadResult
+	closeOnce   sync.Once
+	closeErr    error
+}
+
+type bodyReadResult struct {
+	n   int
+	err error
+}
+
+func (r *responseBodyReader) Read(buffer []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	// the context can't be done, so there's no need to watch it
+	if r.ctx.Done() == nil {
+		return r.reader.Read(buffer)
+	}
+
+	// read into a buffer of our own, which an abandoned read may keep writing to
+	if cap(r.readBuffer) < len(buffer) {
+		r.readBuffer = make([]byte, len(buffer))
+	}
+
+	readBuffer := r.readBuffer[:len(buffer)]
+	readChan := make(chan bodyReadResult, 1)
+
+	go func() {
+		n, err := r.reader.Read(readBuffer)
+		readChan <- bodyReadResult{n: n, err: err}
+	}()
+
+	select {
+	case result := <-readChan:
+		return copy(buffer, readBuffer[:result.n]), result.err
+
+	case <-r.ctx.Done():
+
+		// the read returns at the latest when the deadline passes - the response is released then
+		r.pendingRead = readChan
+		return 0, r.ctx.Err()
+	}
+}
+
+func (r *responseBodyReader) Close() error {
+	r.closeOnce.Do(func() {
+		if r.pendingRead == nil {
+			r.closeErr = r.response.CloseBodyStream()
+			fasthttp.ReleaseResponse(r.response)
+			return
+		}
+
+		// an abandoned read is still using the response
+		go func() {
+			<-r.pendingRead
+			_ = r.response.CloseBodyStream()
+			fasthttp.ReleaseResponse(r.response)
+		}()
+	})
+
+	return r.closeErr
 }
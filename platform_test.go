@@ -0,0 +1,273 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nuclio/logger"
+	"github.com/valyala/fasthttp"
+)
+
+type nopLogger struct{}
+
+func (l nopLogger) Error(interface{}, ...interface{})                         {}
+func (l nopLogger) Warn(interface{}, ...interface{})                          {}
+func (l nopLogger) Info(interface{}, ...interface{})                          {}
+func (l nopLogger) Debug(interface{}, ...interface{})                         {}
+func (l nopLogger) ErrorCtx(context.Context, interface{}, ...interface{})     {}
+func (l nopLogger) WarnCtx(context.Context, interface{}, ...interface{})      {}
+func (l nopLogger) InfoCtx(context.Context, interface{}, ...interface{})      {}
+func (l nopLogger) DebugCtx(context.Context, interface{}, ...interface{})     {}
+func (l nopLogger) ErrorWith(interface{}, ...interface{})                     {}
+func (l nopLogger) WarnWith(interface{}, ...interface{})                      {}
+func (l nopLogger) InfoWith(interface{}, ...interface{})                      {}
+func (l nopLogger) DebugWith(interface{}, ...interface{})                     {}
+func (l nopLogger) ErrorWithCtx(context.Context, interface{}, ...interface{}) {}
+func (l nopLogger) WarnWithCtx(context.Context, interface{}, ...interface{})  {}
+func (l nopLogger) InfoWithCtx(context.Context, interface{}, ...interface{})  {}
+func (l nopLogger) DebugWithCtx(context.Context, interface{}, ...interface{}) {}
+func (l nopLogger) Flush()                                                    {}
+func (l nopLogger) GetChild(string) logger.Logger                             { return l }
+
+// startFunctionServer serves the handler on a local port, returning its address
+func startFunctionServer(t *testing.T, handler fasthttp.RequestHandler) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+
+	server := &fasthttp.Server{Handler: handler}
+	go server.Serve(listener) // nolint: errcheck
+
+	t.Cleanup(func() {
+		_ = server.Shutdown()
+	})
+
+	return listener.Addr().String()
+}
+
+func newTestPlatform(t *testing.T, configuration *PlatformConfiguration) *Platform {
+	platform, err := NewPlatformWithConfiguration(nopLogger{}, "local", "default", configuration)
+	if err != nil {
+		t.Fatalf("Failed to create platform: %s", err)
+	}
+
+	return platform
+}
+
+func TestCallFunction(t *testing.T) {
+	address := startFunctionServer(t, func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+		ctx.Response.Header.Set("X-Path", string(ctx.Path()))
+		ctx.SetBody(append([]byte(`{"echo":`), append(ctx.PostBody(), '}')...))
+	})
+
+	platform := newTestPlatform(t, &PlatformConfiguration{
+		FunctionResolver: StaticFunctionResolver{"echo": address},
+	})
+
+	response, err := platform.CallFunction("echo", &MemoryEvent{Path: "/some/path", Body: []byte(`"hello"`)})
+	if err != nil {
+		t.Fatalf("Call failed: %s", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("Bad status code: %d", response.StatusCode)
+	}
+
+	if response.ContentType != "application/json" || string(response.Body) != `{"echo":"hello"}` {
+		t.Fatalf("Bad response: %s %s", response.ContentType, response.Body)
+	}
+
+	if response.Headers["X-Path"] != "/some/path" {
+		t.Fatalf("Bad path: %v", response.Headers["X-Path"])
+	}
+
+	if _, err := platform.CallFunction("missing", &MemoryEvent{}); err == nil {
+		t.Fatalf("Expected resolution of unknown function to fail")
+	}
+}
+
+func TestCallFunctionStream(t *testing.T) {
+	payload := bytes.Repeat([]byte("0123456789"), 100000)
+
+	address := startFunctionServer(t, func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/octet-stream")
+		ctx.SetBodyStreamWriter(func(writer *bufio.Writer) {
+			for offset := 0; offset < len(payload); offset += 4096 {
+				end := offset + 4096
+				if end > len(payload) {
+					end = len(payload)
+				}
+
+				writer.Write(payload[offset:end]) // nolint: errcheck
+				writer.Flush()                    // nolint: errcheck
+			}
+		})
+	})
+
+	platform := newTestPlatform(t, &PlatformConfiguration{
+		FunctionResolver: StaticFunctionResolver{"large": address},
+	})
+
+	result, err := platform.CallFunctionStream(context.Background(), "large", &MemoryEvent{})
+	if err != nil {
+		t.Fatalf("Call failed: %s", err)
+	}
+
+	if !result.IsStream() || result.GetContentType() != "application/octet-stream" {
+		t.Fatalf("Bad result: stream %v, content type %s", result.IsStream(), result.GetContentType())
+	}
+
+	body := result.GetBody().(io.ReadCloser)
+	defer body.Close() // nolint: errcheck
+
+	readPayload, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %s", err)
+	}
+
+	if !bytes.Equal(readPayload, payload) {
+		t.Fatalf("Bad payload of length %d", len(readPayload))
+	}
+}
+
+// startStalledFunctionServer accepts connections on a local port, reads a request from each and
+// writes the given response prefix (if any) without ever completing the response. Each connection is
+// sent on the returned channel once the client closes it
+func startStalledFunctionServer(t *testing.T, responsePrefix string) (string, chan net.Conn) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+
+	closedConnections := make(chan net.Conn, 10)
+
+	go func() {
+		for {
+			connection, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer connection.Close() // nolint: errcheck
+
+				reader := bufio.NewReader(connection)
+				if _, err := http.ReadRequest(reader); err != nil {
+					return
+				}
+
+				connection.Write([]byte(responsePrefix)) // nolint: errcheck
+
+				// wait for the client to close the connection
+				io.Copy(io.Discard, reader) // nolint: errcheck
+				closedConnections <- connection
+			}()
+		}
+	}()
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	return listener.Addr().String(), closedConnections
+}
+
+func TestCallFunctionStreamUnresponsive(t *testing.T) {
+	address, closedConnections := startStalledFunctionServer(t, "")
+
+	platform := newTestPlatform(t, &PlatformConfiguration{
+		CallTimeout:      100 * time.Millisecond,
+		FunctionResolver: StaticFunctionResolver{"stalled": address},
+	})
+
+	if _, err := platform.CallFunctionStream(context.Background(), "stalled", &MemoryEvent{}); !errors.Is(err, ErrGatewayTimeout) {
+		t.Fatalf("Expected gateway timeout, got: %v", err)
+	}
+
+	// the request doesn't linger once the call timed out
+	select {
+	case <-closedConnections:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Connection to the function was not closed")
+	}
+}
+
+func TestCallFunctionStreamCancelStalledBody(t *testing.T) {
+	address, closedConnections := startStalledFunctionServer(t,
+		"HTTP/1.1 200 OK\r\nContent-Type: text/plain\r\nTransfer-Encoding: chunked\r\n\r\n5\r\nhello\r\n")
+
+	platform := newTestPlatform(t, &PlatformConfiguration{
+		CallTimeout:      time.Second,
+		FunctionResolver: StaticFunctionResolver{"stalled": address},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	result, err := platform.CallFunctionStream(ctx, "stalled", &MemoryEvent{})
+	if err != nil {
+		t.Fatalf("Call failed: %s", err)
+	}
+
+	body := result.GetBody().(io.ReadCloser)
+
+	buffer := make([]byte, 5)
+	if _, err := io.ReadFull(body, buffer); err != nil || string(buffer) != "hello" {
+		t.Fatalf("Bad first chunk: %q, %v", buffer, err)
+	}
+
+	// a read blocked on the stalled body returns once the context is cancelled
+	readErrChan := make(chan error, 1)
+	go func() {
+		_, err := body.Read(buffer)
+		readErrChan <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-readErrChan:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected cancellation, got: %v", err)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatalf("Read was not interrupted by cancellation")
+	}
+
+	if err := body.Close(); err != nil {
+		t.Fatalf("Failed to close body: %s", err)
+	}
+
+	// the abandoned read ends, and the connection is closed, once the call timeout passes
+	select {
+	case <-closedConnections:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Connection to the function was not closed")
+	}
+}
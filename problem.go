@@ -0,0 +1,222 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// FieldError describes why the value of a single field is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+// ProblemError is an error with status code which carries machine readable details, and renders as
+// an RFC 7807 problem details document (application/problem+json)
+type ProblemError struct {
+
+	// Type is a URI identifying the problem type. Defaults to "about:blank"
+	Type string
+
+	// Title is a short summary of the problem type. Defaults to the status text
+	Title string
+
+	// Detail explains this occurrence of the problem
+	Detail string
+
+	// Instance is a URI identifying this occurrence of the problem
+	Instance string
+
+	// Code is an application specific error code
+	Code string
+
+	// Details holds additional members of the problem details document
+	Details map[string]interface{}
+
+	// FieldErrors lists the fields which failed validation, rendered as the "errors" member
+	FieldErrors []FieldError
+
+	statusCode int
+	cause      error
+}
+
+// NewProblemError creates a problem with the given status code and detail
+func NewProblemError(statusCode int, detail string) *ProblemError {
+	return &ProblemError{
+		Detail:     detail,
+		statusCode: statusCode,
+	}
+}
+
+// WrapProblemError creates a problem with the given status code, caused by an existing error
+func WrapProblemError(statusCode int, err error) *ProblemError {
+	return &ProblemError{
+		Detail:     err.Error(),
+		statusCode: statusCode,
+		cause:      err,
+	}
+}
+
+// ProblemFromError converts any error to a problem. Problems in the error chain are returned as is,
+// and otherwise the status code is taken from the first WithStatusCode in the chain (defaulting to
+// 500)
+func ProblemFromError(err error) *ProblemError {
+	var problemError *ProblemError
+	if errors.As(err, &problemError) {
+		return problemError
+	}
+
+	statusCode := http.StatusInternalServerError
+
+	var withStatusCode WithStatusCode
+	if errors.As(err, &withStatusCode) {
+		statusCode = withStatusCode.StatusCode()
+	}
+
+	return WrapProblemError(statusCode, err)
+}
+
+// Error returns the error message
+func (pe *ProblemError) Error() string {
+	if pe.Detail != "" {
+		return pe.Detail
+	}
+
+	return pe.getTitle()
+}
+
+// StatusCode returns the status code
+func (pe *ProblemError) StatusCode() int {
+	return pe.statusCode
+}
+
+// Unwrap returns the error that caused the problem, if any
+func (pe *ProblemError) Unwrap() error {
+	return pe.cause
+}
+
+// Is matches errors with status code (e.g. ErrNotFound) by their status code
+func (pe *ProblemError) Is(target error) bool {
+	switch typedTarget := target.(type) {
+	case ErrorWithStatusCode:
+		return typedTarget.statusCode == pe.statusCode
+	case *ErrorWithStatusCode:
+		return typedTarget != nil && typedTarget.statusCode == pe.statusCode
+	}
+
+	return false
+}
+
+// WithType sets the type of the problem
+func (pe *ProblemError) WithType(problemType string) *ProblemError {
+	pe.Type = problemType
+	return pe
+}
+
+// WithCode sets the application specific error code of the problem
+func (pe *ProblemError) WithCode(code string) *ProblemError {
+	pe.Code = code
+	return pe
+}
+
+// WithDetail adds a member to the problem details document
+func (pe *ProblemError) WithDetail(key string, value interface{}) *ProblemError {
+	if pe.Details == nil {
+		pe.Details = map[string]interface{}{}
+	}
+
+	pe.Details[key] = value
+	return pe
+}
+
+// WithFieldError adds a field which failed validation
+func (pe *ProblemError) WithFieldError(field string, message string) *ProblemError {
+	pe.FieldErrors = append(pe.FieldErrors, FieldError{Field: field, Message: message})
+	return pe
+}
+
+// MarshalJSON renders the problem details document
+func (pe *ProblemError) MarshalJSON() ([]byte, error) {
+	document := make(map[string]interface{}, len(pe.Details)+7)
+
+	// standard members take precedence over extension members
+	for key, value := range pe.Details {
+		document[key] = value
+	}
+
+	document["type"] = pe.Type
+	if pe.Type == "" {
+		document["type"] = "about:blank"
+	}
+
+	document["title"] = pe.getTitle()
+	document["status"] = pe.statusCode
+
+	if pe.Detail != "" {
+		document["detail"] = pe.Detail
+	}
+
+	if pe.Instance != "" {
+		document["instance"] = pe.Instance
+	}
+
+	if pe.Code != "" {
+		document["code"] = pe.Code
+	}
+
+	if len(pe.FieldErrors) > 0 {
+		document["errors"] = pe.FieldErrors
+	}
+
+	return json.Marshal(document)
+}
+
+// Response renders the problem as an application/problem+json response. Handlers return it (rather
+// than the problem itself) for clients to receive the problem details document
+func (pe *ProblemError) Response() Response {
+	body, err := json.Marshal(pe)
+	if err != nil {
+
+		// details that can't be encoded are dropped rather than failing the response
+		body, _ = json.Marshal(&ProblemError{
+			Type:       pe.Type,
+			Title:      pe.Title,
+			Detail:     pe.Detail,
+			Instance:   pe.Instance,
+			Code:       pe.Code,
+			statusCode: pe.statusCode,
+		})
+	}
+
+	return NewResponse(pe.statusCode, ContentTypeProblemJSON, body)
+}
+
+func (pe *ProblemError) getTitle() string {
+	if pe.Title != "" {
+		return pe.Title
+	}
+
+	if message, found := defaultMessages[pe.statusCode]; found {
+		return message
+	}
+
+	return http.StatusText(pe.statusCode)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestProblemError(t *testing.T) {
+	problemError := NewProblemError(http.StatusUnprocessableEntity, "Order is invalid").
+		WithCode("ORDER_INVALID").
+		WithDetail("orderId", "o1").
+		WithFieldError("quantity", "must be positive")
+
+	var err error = fmt.Errorf("handling order: %w", problemError)
+
+	if !errors.Is(err, ErrUnprocessableEntity) {
+		t.Fatalf("Problem should match sentinel with same status code")
+	}
+
+	if errors.Is(err, ErrBadRequest) {
+		t.Fatalf("Problem should not match sentinel with other status code")
+	}
+
+	var withStatusCode WithStatusCode
+	if !errors.As(err, &withStatusCode) || withStatusCode.StatusCode() != http.StatusUnprocessableEntity {
+		t.Fatalf("Problem should be a WithStatusCode")
+	}
+
+	response := problemError.Response()
+	if response.StatusCode != http.StatusUnprocessableEntity || response.ContentType != ContentTypeProblemJSON {
+		t.Fatalf("Bad response: %d %s", response.StatusCode, response.ContentType)
+	}
+
+	document := map[string]interface{}{}
+	if err := json.Unmarshal(response.Body, &document); err != nil {
+		t.Fatalf("Failed to decode problem document: %s", err)
+	}
+
+	for key, expectedValue := range map[string]interface{}{
+		"type":    "about:blank",
+		"title":   "Unprocessable Entity",
+		"status":  float64(http.StatusUnprocessableEntity),
+		"detail":  "Order is invalid",
+		"code":    "ORDER_INVALID",
+		"orderId": "o1",
+	} {
+		if document[key] != expectedValue {
+			t.Fatalf("Bad %s: %v != %v", key, document[key], expectedValue)
+		}
+	}
+
+	if fieldErrors, ok := document["errors"].([]interface{}); !ok || len(fieldErrors) != 1 {
+		t.Fatalf("Bad field errors: %v", document["errors"])
+	}
+}
+
+func TestProblemFromError(t *testing.T) {
+	cause := NewErrConflict("already exists")
+
+	problemError := ProblemFromError(cause)
+	if problemError.StatusCode() != http.StatusConflict || problemError.Detail != "already exists" {
+		t.Fatalf("Bad problem: %d %s", problemError.StatusCode(), problemError.Detail)
+	}
+
+	if !errors.Is(problemError, cause) {
+		t.Fatalf("Problem should wrap its cause")
+	}
+
+	if ProblemFromError(errors.New("boom")).StatusCode() != http.StatusInternalServerError {
+		t.Fatalf("Plain errors should become internal server errors")
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ParseURL returns the parsed URL of the event, falling back to its path for events which have no URL
+func ParseURL(event Event) (*url.URL, error) {
+	rawURL := event.GetURL()
+	if rawURL == "" {
+		rawURL = event.GetPath()
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, WrapErrBadRequest(fmt.Errorf("Invalid URL %q: %w", rawURL, err))
+	}
+
+	return parsedURL, nil
+}
+
+// QueryParams returns the query parameters of the event. Malformed parameters are skipped
+func QueryParams(event Event) url.Values {
+	parsedURL, err := ParseURL(event)
+	if err != nil {
+		return url.Values{}
+	}
+
+	// ParseQuery returns the parameters it managed to parse along with the error
+	values, _ := url.ParseQuery(parsedURL.RawQuery)
+	return values
+}
+
+// QueryParam returns the first value of a query parameter, or an empty string if it is not present
+func QueryParam(event Event, name string) string {
+	return QueryParams(event).Get(name)
+}
+
+// QueryParamInt returns a query parameter as an integer, or the default value if it is not present.
+// Values which are not integers fail with ErrBadRequest
+func QueryParamInt(event Event, name string, defaultValue int) (int, error) {
+	return parseQueryParam(event, name, defaultValue, strconv.Atoi)
+}
+
+// QueryParamBool returns a query parameter as a boolean (e.g. "true", "1", "false"), or the default
+// value if it is not present. Values which are not booleans fail with ErrBadRequest
+func QueryParamBool(event Event, name string, defaultValue bool) (bool, error) {
+	return parseQueryParam(event, name, defaultValue, strconv.ParseBool)
+}
+
+// QueryParamDuration returns a query parameter as a duration (e.g. "1m30s"), or the default value if
+// it is not present. Values which are not durations fail with ErrBadRequest
+func QueryParamDuration(event Event, name string, defaultValue time.Duration) (time.Duration, error) {
+	return parseQueryParam(event, name, defaultValue, time.ParseDuration)
+}
+
+func parseQueryParam[T any](event Event, name string, defaultValue T, parse func(string) (T, error)) (T, error) {
+	values := QueryParams(event)[name]
+	if len(values) == 0 || values[0] == "" {
+		return defaultValue, nil
+	}
+
+	value, err := parse(values[0])
+	if err != nil {
+		return defaultValue, WrapErrBadRequest(fmt.Errorf("Invalid value for query parameter %s: %w", name, err))
+	}
+
+	return value, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQueryParams(t *testing.T) {
+	event := &MemoryEvent{
+		URL: "http://orders:8080/orders?limit=10&verbose=true&timeout=1m30s&tag=a&tag=b&name=a%20b",
+	}
+
+	parsedURL, err := ParseURL(event)
+	if err != nil || parsedURL.Host != "orders:8080" || parsedURL.Path != "/orders" {
+		t.Fatalf("Bad parsed URL: %v, %v", parsedURL, err)
+	}
+
+	if name := QueryParam(event, "name"); name != "a b" {
+		t.Fatalf("Bad query parameter: %q", name)
+	}
+
+	if tags := QueryParams(event)["tag"]; len(tags) != 2 || tags[0] != "a" || tags[1] != "b" {
+		t.Fatalf("Bad multi value query parameter: %v", tags)
+	}
+
+	if limit, err := QueryParamInt(event, "limit", 5); err != nil || limit != 10 {
+		t.Fatalf("Bad int query parameter: %d, %v", limit, err)
+	}
+
+	if offset, err := QueryParamInt(event, "offset", 5); err != nil || offset != 5 {
+		t.Fatalf("Expected default for missing query parameter: %d, %v", offset, err)
+	}
+
+	if verbose, err := QueryParamBool(event, "verbose", false); err != nil || !verbose {
+		t.Fatalf("Bad bool query parameter: %t, %v", verbose, err)
+	}
+
+	if timeout, err := QueryParamDuration(event, "timeout", 0); err != nil || timeout != 90*time.Second {
+		t.Fatalf("Bad duration query parameter: %s, %v", timeout, err)
+	}
+
+	if _, err := QueryParamInt(event, "name", 0); !errors.Is(err, ErrBadRequest) {
+		t.Fatalf("Expected bad request for invalid int, got %v", err)
+	}
+}
+
+func TestQueryParamsFromPath(t *testing.T) {
+	event := &MemoryEvent{Path: "/orders?limit=3"}
+
+	if limit, err := QueryParamInt(event, "limit", 0); err != nil || limit != 3 {
+		t.Fatalf("Bad int query parameter: %d, %v", limit, err)
+	}
+
+	if params := QueryParams(&MemoryEvent{}); len(params) != 0 {
+		t.Fatalf("Expected no query parameters, got %v", params)
+	}
+}
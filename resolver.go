@@ -0,0 +1,193 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultFunctionPort is the port on which functions listen by default
+const DefaultFunctionPort = 8080
+
+// DefaultClusterDomain is the default domain of kubernetes services
+const DefaultClusterDomain = "cluster.local"
+
+// DefaultFunctionAddressEnvPrefix is the prefix of environment variables overriding function addresses
+const DefaultFunctionAddressEnvPrefix = "NUCLIO_FUNCTION_ADDRESS_"
+
+// ErrFunctionNotResolved is returned when a resolver does not know the address of a function
+var ErrFunctionNotResolved = errors.New("Cannot resolve function address")
+
+// FunctionResolver resolves the address of a function by its name. Addresses are given as host:port,
+// optionally prefixed by a scheme (e.g. https://my-function:443)
+type FunctionResolver interface {
+
+	// ResolveFunctionAddress returns the address of the function
+	ResolveFunctionAddress(functionName string) (string, error)
+}
+
+// FunctionResolverFunc allows using an ordinary function as a FunctionResolver
+type FunctionResolverFunc func(functionName string) (string, error)
+
+// ResolveFunctionAddress returns the address of the function
+func (frf FunctionResolverFunc) ResolveFunctionAddress(functionName string) (string, error) {
+	return frf(functionName)
+}
+
+// LocalFunctionResolver resolves functions deployed by the local (docker) platform, whose containers
+// are named nuclio-<namespace>-<name>. Function names may be qualified as <namespace>/<name>
+type LocalFunctionResolver struct {
+	Namespace string
+	Port      int
+}
+
+// ResolveFunctionAddress returns the address of the function
+func (lfr *LocalFunctionResolver) ResolveFunctionAddress(functionName string) (string, error) {
+	namespace, name := splitFunctionName(functionName, lfr.Namespace)
+
+	return fmt.Sprintf("nuclio-%s-%s:%d", namespace, name, getPortOrDefault(lfr.Port)), nil
+}
+
+// KubeFunctionResolver resolves functions deployed on kubernetes through their service. Unqualified
+// function names resolve to the service in the current namespace, while names qualified as
+// <namespace>/<name> resolve to the fully qualified service name in that namespace
+type KubeFunctionResolver struct {
+	Namespace     string
+	ClusterDomain string
+	Port          int
+}
+
+// ResolveFunctionAddress returns the address of the function
+func (kfr *KubeFunctionResolver) ResolveFunctionAddress(functionName string) (string, error) {
+	port := getPortOrDefault(kfr.Port)
+
+	namespace, name := splitFunctionName(functionName, kfr.Namespace)
+	if namespace == kfr.Namespace {
+		return fmt.Sprintf("nuclio-%s:%d", name, port), nil
+	}
+
+	clusterDomain := kfr.ClusterDomain
+	if clusterDomain == "" {
+		clusterDomain = DefaultClusterDomain
+	}
+
+	return fmt.Sprintf("nuclio-%s.%s.svc.%s:%d", name, namespace, clusterDomain, port), nil
+}
+
+// StaticFunctionResolver resolves functions from a fixed map of function name to address
+type StaticFunctionResolver map[string]string
+
+// ResolveFunctionAddress returns the address of the function
+func (sfr StaticFunctionResolver) ResolveFunctionAddress(functionName string) (string, error) {
+	address, found := sfr[functionName]
+	if !found {
+		return "", ErrFunctionNotResolved
+	}
+
+	return address, nil
+}
+
+// EnvFunctionResolver resolves functions from environment variables named <prefix><NAME>, where NAME
+// is the function name in upper case with any character other than letters and digits replaced by
+// an underscore (e.g. NUCLIO_FUNCTION_ADDRESS_MY_FUNCTION)
+type EnvFunctionResolver struct {
+	Prefix string
+}
+
+// ResolveFunctionAddress returns the address of the function
+func (efr *EnvFunctionResolver) ResolveFunctionAddress(functionName string) (string, error) {
+	prefix := efr.Prefix
+	if prefix == "" {
+		prefix = DefaultFunctionAddressEnvPrefix
+	}
+
+	address := os.Getenv(prefix + functionNameToEnvSuffix(functionName))
+	if address == "" {
+		return "", ErrFunctionNotResolved
+	}
+
+	return address, nil
+}
+
+// ChainFunctionResolver consults resolvers in order, returning the first address resolved. Resolvers
+// returning ErrFunctionNotResolved are skipped, while any other error is returned as is
+type ChainFunctionResolver []FunctionResolver
+
+// ResolveFunctionAddress returns the address of the function
+func (cfr ChainFunctionResolver) ResolveFunctionAddress(functionName string) (string, error) {
+	for _, resolver := range cfr {
+		address, err := resolver.ResolveFunctionAddress(functionName)
+		if err == nil {
+			return address, nil
+		}
+
+		if !errors.Is(err, ErrFunctionNotResolved) {
+			return "", err
+		}
+	}
+
+	return "", ErrFunctionNotResolved
+}
+
+// NewDefaultFunctionResolver returns the resolver used by a platform of the given kind: environment
+// variable overrides first, followed by the local or kubernetes resolver
+func NewDefaultFunctionResolver(kind string, namespace string) FunctionResolver {
+	var platformResolver FunctionResolver
+
+	if kind == "local" {
+		platformResolver = &LocalFunctionResolver{Namespace: namespace}
+	} else {
+		platformResolver = &KubeFunctionResolver{Namespace: namespace}
+	}
+
+	return ChainFunctionResolver{
+		&EnvFunctionResolver{},
+		platformResolver,
+	}
+}
+
+func splitFunctionName(functionName string, defaultNamespace string) (string, string) {
+	if separatorIndex := strings.Index(functionName, "/"); separatorIndex != -1 {
+		return functionName[:separatorIndex], functionName[separatorIndex+1:]
+	}
+
+	return defaultNamespace, functionName
+}
+
+func functionNameToEnvSuffix(functionName string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, functionName)
+}
+
+func getPortOrDefault(port int) int {
+	if port == 0 {
+		return DefaultFunctionPort
+	}
+
+	return port
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"testing"
+)
+
+func TestFunctionResolvers(t *testing.T) {
+	t.Setenv("NUCLIO_FUNCTION_ADDRESS_MY_FUNCTION", "override:9090")
+
+	for _, testCase := range []struct {
+		name            string
+		resolver        FunctionResolver
+		functionName    string
+		expectedAddress string
+	}{
+		{
+			name:            "local",
+			resolver:        NewDefaultFunctionResolver("local", "default"),
+			functionName:    "echo",
+			expectedAddress: "nuclio-default-echo:8080",
+		},
+		{
+			name:            "kube",
+			resolver:        NewDefaultFunctionResolver("kube", "default"),
+			functionName:    "echo",
+			expectedAddress: "nuclio-echo:8080",
+		},
+		{
+			name:            "kubeOtherNamespace",
+			resolver:        &KubeFunctionResolver{Namespace: "default", Port: 8081},
+			functionName:    "other/echo",
+			expectedAddress: "nuclio-echo.other.svc.cluster.local:8081",
+		},
+		{
+			name:            "envOverride",
+			resolver:        NewDefaultFunctionResolver("kube", "default"),
+			functionName:    "my-function",
+			expectedAddress: "override:9090",
+		},
+		{
+			name: "chain",
+			resolver: ChainFunctionResolver{
+				StaticFunctionResolver{"a": "a:1"},
+				StaticFunctionResolver{"echo": "echo:2"},
+			},
+			functionName:    "echo",
+			expectedAddress: "echo:2",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			address, err := testCase.resolver.ResolveFunctionAddress(testCase.functionName)
+			if err != nil {
+				t.Fatalf("Failed to resolve: %s", err)
+			}
+
+			if address != testCase.expectedAddress {
+				t.Fatalf("Bad address: %q != %q", address, testCase.expectedAddress)
+			}
+		})
+	}
+}
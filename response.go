@@ -44,7 +44,7 @@ type ProcessingResult interface {
 type Response struct {
 	StatusCode  int
 	ContentType string
-	Headers     map[string]interface{}
+	Headers     Headers
 	Body        []byte
 }
 
@@ -0,0 +1,206 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// DefaultMaxRetryAfter is the longest Retry-After honored, unless configured otherwise through
+// RetryPolicy.MaxRetryAfter
+const DefaultMaxRetryAfter = 30 * time.Second
+
+// RetryPolicy controls how the platform retries failed calls to other functions
+type RetryPolicy struct {
+
+	// MaxAttempts is the maximum number of attempts, including the first one
+	MaxAttempts int
+
+	// InitialBackoff is the duration to wait before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the duration to wait between attempts
+	MaxBackoff time.Duration
+
+	// BackoffMultiplier is the factor by which the backoff grows after every attempt
+	BackoffMultiplier float64
+
+	// Jitter is the fraction (0 to 1) of the backoff that is randomized, to avoid retrying in lockstep
+	Jitter float64
+
+	// RetryableStatusCodes holds the response status codes that cause a retry
+	RetryableStatusCodes []int
+
+	// RetryNonIdempotent retries requests with non idempotent methods (e.g. POST) which may have been
+	// handled by the function, like those that timed out reading the response or were responded to
+	// with 504. Since retrying may repeat their side effects, unless this is set they are only retried
+	// if the request was never sent (e.g. the connection was refused) or the function responded with
+	// 429 or 503 and a Retry-After header. Requests with idempotent methods are always retried
+	RetryNonIdempotent bool
+
+	// IgnoreRetryAfter disables honoring the Retry-After header of retryable responses
+	IgnoreRetryAfter bool
+
+	// MaxRetryAfter is the longest Retry-After honored. Responses asking to wait longer are returned
+	// as is rather than retried. Zero means DefaultMaxRetryAfter
+	MaxRetryAfter time.Duration
+}
+
+// NewDefaultRetryPolicy returns a policy of 3 attempts with exponential backoff, retrying on the
+// status codes for which IsRetryableStatusCode is true
+func NewDefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          3,
+		InitialBackoff:       100 * time.Millisecond,
+		MaxBackoff:           5 * time.Second,
+		BackoffMultiplier:    2,
+		Jitter:               0.2,
+		RetryableStatusCodes: append([]int(nil), retryableStatusCodes...),
+	}
+}
+
+func (rp *RetryPolicy) getMaxAttempts() int {
+	if rp == nil || rp.MaxAttempts < 1 {
+		return 1
+	}
+
+	return rp.MaxAttempts
+}
+
+func (rp *RetryPolicy) isRetryableStatusCode(statusCode int) bool {
+	if rp == nil {
+		return false
+	}
+
+	for _, retryableStatusCode := range rp.RetryableStatusCodes {
+		if statusCode == retryableStatusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRetryableError checks if a transport error of a request with the given method may be retried
+// isRetryableResult checks if the result has a retryable status code, and the request either has an
+// idempotent method or was rejected by the function without being processed
+func (rp *RetryPolicy) isRetryableResult(method string, result ProcessingResult) bool {
+	if !rp.isRetryableStatusCode(result.GetStatusCode()) {
+		return false
+	}
+
+	if rp.RetryNonIdempotent || isIdempotentMethod(method) {
+		return true
+	}
+
+	switch result.GetStatusCode() {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		_, hasRetryAfter := getRetryAfter(result.GetHeaders())
+		return hasRetryAfter
+	}
+
+	return false
+}
+
+func (rp *RetryPolicy) isRetryableError(method string, err error) bool {
+	if rp == nil {
+		return false
+	}
+
+	return rp.RetryNonIdempotent || isIdempotentMethod(method) || isUnsentRequestError(err)
+}
+
+func (rp *RetryPolicy) getMaxRetryAfter() time.Duration {
+	if rp.MaxRetryAfter > 0 {
+		return rp.MaxRetryAfter
+	}
+
+	return DefaultMaxRetryAfter
+}
+
+// getBackoff returns the duration to wait after the given (1 based) attempt failed
+func (rp *RetryPolicy) getBackoff(attempt int) time.Duration {
+	multiplier := rp.BackoffMultiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(rp.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if rp.MaxBackoff > 0 && backoff > float64(rp.MaxBackoff) {
+		backoff = float64(rp.MaxBackoff)
+	}
+
+	if rp.Jitter > 0 {
+		jitter := math.Min(rp.Jitter, 1)
+		backoff -= backoff * jitter * rand.Float64()
+	}
+
+	return time.Duration(backoff)
+}
+
+func isIdempotentMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// isUnsentRequestError checks if the error happened before the request was sent, while connecting
+func isUnsentRequestError(err error) bool {
+	if errors.Is(err, fasthttp.ErrDialTimeout) || errors.Is(err, fasthttp.ErrNoFreeConns) {
+		return true
+	}
+
+	var opError *net.OpError
+	return errors.As(err, &opError) && opError.Op == "dial"
+}
+
+// getRetryAfter parses a Retry-After header value, given either in seconds or as an HTTP date
+func getRetryAfter(headers map[string]interface{}) (time.Duration, bool) {
+	headerValueString, ok := Headers(headers).Get("Retry-After").(string)
+	if !ok {
+		return 0, false
+	}
+
+	headerValueString = strings.TrimSpace(headerValueString)
+
+	if seconds, err := strconv.Atoi(headerValueString); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if retryTime, err := http.ParseTime(headerValueString); err == nil {
+		retryAfter := time.Until(retryTime)
+		if retryAfter < 0 {
+			retryAfter = 0
+		}
+
+		return retryAfter, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	retryPolicy := &RetryPolicy{
+		MaxAttempts:       5,
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        300 * time.Millisecond,
+		BackoffMultiplier: 2,
+	}
+
+	for attempt, expectedBackoff := range []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		300 * time.Millisecond,
+		300 * time.Millisecond,
+	} {
+		if backoff := retryPolicy.getBackoff(attempt + 1); backoff != expectedBackoff {
+			t.Fatalf("Bad backoff for attempt %d: %s != %s", attempt+1, backoff, expectedBackoff)
+		}
+	}
+
+	retryPolicy.Jitter = 0.5
+	for attempt := 1; attempt <= 10; attempt++ {
+		if backoff := retryPolicy.getBackoff(1); backoff < 50*time.Millisecond || backoff > 100*time.Millisecond {
+			t.Fatalf("Jittered backoff out of range: %s", backoff)
+		}
+	}
+}
+
+func TestRetryPolicyNil(t *testing.T) {
+	var retryPolicy *RetryPolicy
+
+	if retryPolicy.getMaxAttempts() != 1 {
+		t.Fatalf("Nil policy should attempt once")
+	}
+
+	if retryPolicy.isRetryableStatusCode(http.StatusServiceUnavailable) {
+		t.Fatalf("Nil policy should not retry")
+	}
+}
+
+func TestDefaultRetryPolicyStatusCodes(t *testing.T) {
+	retryPolicy := NewDefaultRetryPolicy()
+
+	for statusCode := 100; statusCode < 600; statusCode++ {
+		if retryPolicy.isRetryableStatusCode(statusCode) != IsRetryableStatusCode(statusCode) {
+			t.Fatalf("Default retry policy and IsRetryableStatusCode disagree on %d", statusCode)
+		}
+	}
+
+	// the policy owns its status codes
+	retryPolicy.RetryableStatusCodes[0] = http.StatusOK
+	if IsRetryableStatusCode(http.StatusOK) {
+		t.Fatalf("Modifying the policy changed IsRetryableStatusCode")
+	}
+}
+
+func TestGetRetryAfter(t *testing.T) {
+	retryAfter, ok := getRetryAfter(map[string]interface{}{"retry-after": "3"})
+	if !ok || retryAfter != 3*time.Second {
+		t.Fatalf("Bad Retry-After in seconds: %s", retryAfter)
+	}
+
+	retryTime := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	retryAfter, ok = getRetryAfter(map[string]interface{}{"Retry-After": retryTime})
+	if !ok || retryAfter <= 59*time.Minute || retryAfter > time.Hour {
+		t.Fatalf("Bad Retry-After as date: %s", retryAfter)
+	}
+
+	if _, ok := getRetryAfter(map[string]interface{}{"Retry-After": "soon"}); ok {
+		t.Fatalf("Invalid Retry-After should be ignored")
+	}
+}
+
+func TestCallFunctionRetries(t *testing.T) {
+	var numRequests int32
+
+	address := startFunctionServer(t, func(ctx *fasthttp.RequestCtx) {
+		if atomic.AddInt32(&numRequests, 1) < 3 {
+			ctx.Response.Header.Set("Retry-After", "0")
+			ctx.SetStatusCode(http.StatusServiceUnavailable)
+			return
+		}
+
+		ctx.SetStatusCode(http.StatusOK)
+	})
+
+	retryPolicy := NewDefaultRetryPolicy()
+	retryPolicy.MaxAttempts = 3
+
+	platform := newTestPlatform(t, &PlatformConfiguration{
+		FunctionResolver: StaticFunctionResolver{"flaky": address},
+		RetryPolicy:      retryPolicy,
+	})
+
+	response, err := platform.CallFunction("flaky", &MemoryEvent{})
+	if err != nil {
+		t.Fatalf("Call failed: %s", err)
+	}
+
+	if response.StatusCode != http.StatusOK || atomic.LoadInt32(&numRequests) != 3 {
+		t.Fatalf("Expected success on third attempt, got %d after %d", response.StatusCode, numRequests)
+	}
+}
+
+func TestCallFunctionRetryAfterLimit(t *testing.T) {
+	var numRequests int32
+
+	address := startFunctionServer(t, func(ctx *fasthttp.RequestCtx) {
+		atomic.AddInt32(&numRequests, 1)
+		ctx.Response.Header.Set("Retry-After", "3600")
+		ctx.SetStatusCode(http.StatusTooManyRequests)
+	})
+
+	platform := newTestPlatform(t, &PlatformConfiguration{
+		FunctionResolver: StaticFunctionResolver{"busy": address},
+		RetryPolicy:      NewDefaultRetryPolicy(),
+	})
+
+	startTime := time.Now()
+
+	response, err := platform.CallFunction("busy", &MemoryEvent{})
+	if err != nil || response.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("Expected the too many requests response, got %d, %v", response.StatusCode, err)
+	}
+
+	if atomic.LoadInt32(&numRequests) != 1 || time.Since(startTime) > time.Second {
+		t.Fatalf("Expected no retry past the maximum Retry-After")
+	}
+}
+
+func TestCallFunctionRetriesTransportErrors(t *testing.T) {
+	var numRequests int32
+
+	address := startFunctionServer(t, func(ctx *fasthttp.RequestCtx) {
+		atomic.AddInt32(&numRequests, 1)
+		time.Sleep(200 * time.Millisecond)
+	})
+
+	retryPolicy := NewDefaultRetryPolicy()
+	retryPolicy.InitialBackoff = time.Millisecond
+
+	platform := newTestPlatform(t, &PlatformConfiguration{
+		CallTimeout:      50 * time.Millisecond,
+		FunctionResolver: StaticFunctionResolver{"slow": address},
+		RetryPolicy:      retryPolicy,
+	})
+
+	// a POST which timed out may have been handled, so it isn't retried
+	if _, err := platform.CallFunction("slow", &MemoryEvent{Method: "POST"}); !errors.Is(err, ErrGatewayTimeout) {
+		t.Fatalf("Expected gateway timeout, got %v", err)
+	}
+
+	if numRequests := atomic.LoadInt32(&numRequests); numRequests != 1 {
+		t.Fatalf("Expected a single attempt for POST, got %d", numRequests)
+	}
+
+	// an idempotent GET is
+	atomic.StoreInt32(&numRequests, 0)
+	if _, err := platform.CallFunction("slow", &MemoryEvent{Method: "GET"}); !errors.Is(err, ErrGatewayTimeout) {
+		t.Fatalf("Expected gateway timeout, got %v", err)
+	}
+
+	if numRequests := atomic.LoadInt32(&numRequests); numRequests != 3 {
+		t.Fatalf("Expected three attempts for GET, got %d", numRequests)
+	}
+
+	// a POST responded to with 504 may have been handled as well, so it isn't retried
+	address = startFunctionServer(t, func(ctx *fasthttp.RequestCtx) {
+		atomic.AddInt32(&numRequests, 1)
+		ctx.SetStatusCode(http.StatusGatewayTimeout)
+	})
+
+	platform = newTestPlatform(t, &PlatformConfiguration{
+		FunctionResolver: StaticFunctionResolver{"timingOut": address},
+		RetryPolicy:      retryPolicy,
+	})
+
+	atomic.StoreInt32(&numRequests, 0)
+	response, err := platform.CallFunction("timingOut", &MemoryEvent{Method: "POST"})
+	if err != nil || response.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("Expected gateway timeout response, got %d, %v", response.StatusCode, err)
+	}
+
+	if numRequests := atomic.LoadInt32(&numRequests); numRequests != 1 {
+		t.Fatalf("Expected a single attempt for POST, got %d", numRequests)
+	}
+
+	// a POST rejected with Retry-After wasn't processed, so it is retried
+	rejectedResponse := &Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Headers:    map[string]interface{}{"Retry-After": "1"},
+	}
+
+	if !retryPolicy.isRetryableResult("POST", rejectedResponse) {
+		t.Fatalf("Expected rejected POST to be retryable")
+	}
+
+	// a POST which was never sent is retried
+	if !retryPolicy.isRetryableError("POST", &net.OpError{Op: "dial", Err: errors.New("connection refused")}) {
+		t.Fatalf("Expected dial errors to be retryable")
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Router dispatches events to handlers by their method and path, for functions serving more than a
+// single endpoint. Paths are matched against patterns like /users/{id}/files/{path...}, where {name}
+// captures a single segment, * matches any single segment and a trailing {name...} captures the rest
+// of the path. When several patterns match a path, the most specific one (literal segments before
+// parameters, parameters before wildcards) is chosen. Handlers read the captured parameters with
+// PathParam and PathParams.
+//
+// Handlers receive the routed event wrapped in an event carrying the path parameters, rather than
+// the event the router was given. Handlers which type assert the event to its concrete type (for
+// example *MemoryEvent) must assert UnwrapEvent(event) instead
+type Router struct {
+	routes []*route
+}
+
+type route struct {
+	method  string
+	pattern *pathPattern
+	handler Handler
+}
+
+type routedEvent struct {
+	Event
+	params map[string]string
+}
+
+// Unwrap returns the event the router was given
+func (re *routedEvent) Unwrap() Event {
+	return re.Event
+}
+
+// NewRouter creates a new router, with no routes
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Handle routes events with the given method (any method if empty) whose path matches the pattern
+// to the handler
+func (r *Router) Handle(method string, pattern string, handler Handler) *Router {
+	r.routes = append(r.routes, &route{
+		method:  strings.ToUpper(method),
+		pattern: newPathPattern(pattern),
+		handler: handler,
+	})
+
+	return r
+}
+
+// Get routes GET (and HEAD, unless routed explicitly) events matching the pattern to the handler
+func (r *Router) Get(pattern string, handler Handler) *Router {
+	return r.Handle(http.MethodGet, pattern, handler)
+}
+
+// Post routes POST events matching the pattern to the handler
+func (r *Router) Post(pattern string, handler Handler) *Router {
+	return r.Handle(http.MethodPost, pattern, handler)
+}
+
+// Put routes PUT events matching the pattern to the handler
+func (r *Router) Put(pattern string, handler Handler) *Router {
+	return r.Handle(http.MethodPut, pattern, handler)
+}
+
+// Patch routes PATCH events matching the pattern to the handler
+func (r *Router) Patch(pattern string, handler Handler) *Router {
+	return r.Handle(http.MethodPatch, pattern, handler)
+}
+
+// Delete routes DELETE events matching the pattern to the handler
+func (r *Router) Delete(pattern string, handler Handler) *Router {
+	return r.Handle(http.MethodDelete, pattern, handler)
+}
+
+// Route dispatches the event to the handler of the most specific matching route, and can be used
+// as the function's handler. Events whose path matches no route fail with ErrNotFound, and events
+// whose path matches routes of other methods fail with ErrMethodNotAllowed, listing the allowed
+// methods in the Allow header
+func (r *Router) Route(context *Context, event Event) (interface{}, error) {
+	method := strings.ToUpper(event.GetMethod())
+	path := event.GetPath()
+
+	var pathRoutes []*route
+	var pathParams []map[string]string
+
+	for _, candidateRoute := range r.routes {
+		if params, matched := candidateRoute.pattern.match(path); matched {
+			pathRoutes = append(pathRoutes, candidateRoute)
+			pathParams = append(pathParams, params)
+		}
+	}
+
+	if len(pathRoutes) == 0 {
+		return nil, NewErrNotFound(fmt.Sprintf("No route for %s", path))
+	}
+
+	// try the requested method, and serve HEAD from GET if it isn't routed explicitly
+	methods := []string{method}
+	if method == http.MethodHead {
+		methods = append(methods, http.MethodGet)
+	}
+
+	for _, candidateMethod := range methods {
+		var matchedRoute *route
+		var matchedParams map[string]string
+
+		for routeIndex, candidateRoute := range pathRoutes {
+			if candidateRoute.method != "" && candidateRoute.method != candidateMethod {
+				continue
+			}
+
+			if matchedRoute == nil || candidateRoute.pattern.isMoreSpecificThan(matchedRoute.pattern) {
+				matchedRoute = candidateRoute
+				matchedParams = pathParams[routeIndex]
+			}
+		}
+
+		if matchedRoute != nil {
+			return matchedRoute.handler(context, &routedEvent{
+				Event:  event,
+				params: matchedParams,
+			})
+		}
+	}
+
+	methodNotAllowedErr := NewErrMethodNotAllowedf("Method %s is not allowed for %s", method, path)
+
+	return nil, methodNotAllowedErr.(*ErrorWithStatusCode).WithHeader("Allow",
+		strings.Join(getAllowedMethods(pathRoutes), ", "))
+}
+
+// UnwrapEvent returns the event wrapped by events which carry additional state, like the events
+// routers pass to handlers, so that it can be type asserted to its concrete type. Wrapping events
+// implement Unwrap() Event
+func UnwrapEvent(event Event) Event {
+	for {
+		wrapper, ok := event.(interface{ Unwrap() Event })
+		if !ok {
+			return event
+		}
+
+		event = wrapper.Unwrap()
+	}
+}
+
+// PathParams returns the path parameters captured by the route the router dispatched the event to.
+// When routers are nested, these are the parameters captured by the innermost router
+func PathParams(event Event) map[string]string {
+	for {
+		if routed, ok := event.(*routedEvent); ok {
+			return routed.params
+		}
+
+		wrapper, ok := event.(interface{ Unwrap() Event })
+		if !ok {
+			return map[string]string{}
+		}
+
+		event = wrapper.Unwrap()
+	}
+}
+
+// PathParam returns a path parameter captured by the route the router dispatched the event to, or
+// an empty string if there is no such parameter
+func PathParam(event Event, name string) string {
+	return PathParams(event)[name]
+}
+
+func getAllowedMethods(routes []*route) []string {
+	methodSet := map[string]bool{}
+	for _, allowedRoute := range routes {
+		methodSet[allowedRoute.method] = true
+	}
+
+	if methodSet[http.MethodGet] {
+		methodSet[http.MethodHead] = true
+	}
+
+	methods := make([]string, 0, len(methodSet))
+	for method := range methodSet {
+		methods = append(methods, method)
+	}
+
+	sort.Strings(methods)
+	return methods
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestRouter(t *testing.T) {
+	respondWith := func(name string) Handler {
+		return func(context *Context, event Event) (interface{}, error) {
+			return name + " " + PathParam(event, "id") + PathParam(event, "path"), nil
+		}
+	}
+
+	router := NewRouter().
+		Get("/users/{id}", respondWith("getUser")).
+		Get("/users/me", respondWith("getMe")).
+		Delete("/users/{id}", respondWith("deleteUser")).
+		Get("/files/{path...}", respondWith("getFile")).
+		Handle("", "/health", respondWith("health"))
+
+	for _, testCase := range []struct {
+		method         string
+		path           string
+		expectedResult string
+	}{
+		{"GET", "/users/42", "getUser 42"},
+		{"get", "/users/me", "getMe "},
+		{"DELETE", "/users/42", "deleteUser 42"},
+		{"HEAD", "/users/42", "getUser 42"},
+		{"GET", "/files/a/b.txt", "getFile a/b.txt"},
+		{"POST", "/health", "health "},
+	} {
+		result, err := router.Route(nil, &MemoryEvent{Method: testCase.method, Path: testCase.path})
+		if err != nil {
+			t.Fatalf("Failed routing %s %s: %s", testCase.method, testCase.path, err)
+		}
+
+		if result != testCase.expectedResult {
+			t.Fatalf("Bad result for %s %s: %v", testCase.method, testCase.path, result)
+		}
+	}
+
+	if _, err := router.Route(nil, &MemoryEvent{Method: "GET", Path: "/orders/1"}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected not found, got %v", err)
+	}
+
+	_, err := router.Route(nil, &MemoryEvent{Method: "PUT", Path: "/users/42"})
+	if !errors.Is(err, ErrMethodNotAllowed) {
+		t.Fatalf("Expected method not allowed, got %v", err)
+	}
+
+	var withResponse WithResponse
+	if !errors.As(err, &withResponse) || withResponse.ResponseHeaders()["Allow"] != "DELETE, GET, HEAD" {
+		t.Fatalf("Bad Allow header: %v", withResponse.ResponseHeaders())
+	}
+
+	result := ErrorToProcessingResult(err)
+	if result.GetStatusCode() != http.StatusMethodNotAllowed || result.GetHeaders()["Allow"] != "DELETE, GET, HEAD" {
+		t.Fatalf("Bad processing result: %d %v", result.GetStatusCode(), result.GetHeaders())
+	}
+}
+
+func TestPathParamsOfUnroutedEvent(t *testing.T) {
+	if params := PathParams(&MemoryEvent{}); params == nil || len(params) != 0 {
+		t.Fatalf("Expected empty params, got %v", params)
+	}
+}
+
+type wrappedEvent struct {
+	Event
+}
+
+func (we *wrappedEvent) Unwrap() Event {
+	return we.Event
+}
+
+func TestRoutedEventUnwrap(t *testing.T) {
+	router := NewRouter().Get("/users/{id}", func(context *Context, event Event) (interface{}, error) {
+		memoryEvent, ok := UnwrapEvent(event).(*MemoryEvent)
+		if !ok {
+			t.Fatalf("Routed event does not unwrap to the original event: %T", UnwrapEvent(event))
+		}
+
+		// params are still visible through events wrapping the routed event
+		return memoryEvent.Path + " " + PathParam(&wrappedEvent{Event: event}, "id"), nil
+	})
+
+	result, err := router.Route(nil, &MemoryEvent{Method: "GET", Path: "/users/42"})
+	if err != nil {
+		t.Fatalf("Failed routing: %s", err)
+	}
+
+	if result != "/users/42 42" {
+		t.Fatalf("Bad result: %v", result)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"errors"
+	"net/http"
+)
+
+// IsRedirectStatusCode checks if the status code is a redirect (3xx)
+func IsRedirectStatusCode(statusCode int) bool {
+	return statusCode >= 300 && statusCode < 400
+}
+
+// IsClientErrorStatusCode checks if the status code is a client error (4xx)
+func IsClientErrorStatusCode(statusCode int) bool {
+	return statusCode >= 400 && statusCode < 500
+}
+
+// IsServerErrorStatusCode checks if the status code is a server error (5xx)
+func IsServerErrorStatusCode(statusCode int) bool {
+	return statusCode >= 500 && statusCode < 600
+}
+
+// retryableStatusCodes holds the status codes of transient failures. It is shared by
+// IsRetryableStatusCode and the default retry policy, so that both agree on what is retried
+var retryableStatusCodes = []int{
+	http.StatusRequestTimeout,
+	http.StatusTooEarly,
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+	StatusNetworkReadTimeout,
+	StatusNetworkConnectTimeout,
+}
+
+// IsRetryableStatusCode checks if the status code indicates a transient failure, after which the
+// same request may succeed. These are the status codes retried by the default retry policy
+func IsRetryableStatusCode(statusCode int) bool {
+	for _, retryableStatusCode := range retryableStatusCodes {
+		if statusCode == retryableStatusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsRedirect checks if the error is a redirect (e.g. created by NewRedirect)
+func IsRedirect(err error) bool {
+	return err != nil && IsRedirectStatusCode(StatusCodeOf(err))
+}
+
+// IsClientError checks if the error has a client error status code
+func IsClientError(err error) bool {
+	return err != nil && IsClientErrorStatusCode(StatusCodeOf(err))
+}
+
+// IsServerError checks if the error has a server error status code. Errors without a status code
+// are considered internal server errors
+func IsServerError(err error) bool {
+	return err != nil && IsServerErrorStatusCode(StatusCodeOf(err))
+}
+
+// IsRetryable checks if the error has a status code indicating a transient failure
+func IsRetryable(err error) bool {
+	return err != nil && IsRetryableStatusCode(StatusCodeOf(err))
+}
+
+// IsRedirectResult checks if the result is a redirect
+func IsRedirectResult(result ProcessingResult) bool {
+	return IsRedirectStatusCode(result.GetStatusCode())
+}
+
+// IsClientErrorResult checks if the result has a client error status code
+func IsClientErrorResult(result ProcessingResult) bool {
+	return IsClientErrorStatusCode(result.GetStatusCode())
+}
+
+// IsServerErrorResult checks if the result has a server error status code
+func IsServerErrorResult(result ProcessingResult) bool {
+	return IsServerErrorStatusCode(result.GetStatusCode())
+}
+
+// IsRetryableResult checks if the result has a status code indicating a transient failure
+func IsRetryableResult(result ProcessingResult) bool {
+	return IsRetryableStatusCode(result.GetStatusCode())
+}
+
+// ErrorToProcessingResult converts an error into the response sent when a handler returns it: the
+// status code of the error (500 if it has none) and its message as a text/plain body, unless the
+// error specifies headers or a body of its own. Like any other error, returned problems are rendered
+// as text - handlers return ProblemError.Response() for clients to receive problem details documents
+func ErrorToProcessingResult(err error) ProcessingResult {
+	var withResponse WithResponse
+	if !errors.As(err, &withResponse) {
+		response := NewResponse(StatusCodeOf(err), ContentTypeText, []byte(err.Error()))
+		return &response
+	}
+
+	response := NewResponse(StatusCodeOf(err), ContentTypeText, []byte(err.Error()))
+	if body := withResponse.ResponseBody(); body != nil {
+		contentType := withResponse.ResponseContentType()
+		if contentType == "" {
+			contentType = ContentTypeOctetStream
+		}
+
+		response = NewResponse(response.StatusCode, contentType, body)
+	}
+
+	for headerKey, headerValue := range withResponse.ResponseHeaders() {
+		response.Headers.Set(headerKey, headerValue)
+	}
+
+	return &response
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestErrorClassification(t *testing.T) {
+	for _, testCase := range []struct {
+		name                string
+		err                 error
+		expectedClientError bool
+		expectedServerError bool
+		expectedRetryable   bool
+		expectedRedirect    bool
+	}{
+		{name: "nil"},
+		{name: "notFound", err: NewErrNotFound("missing"), expectedClientError: true},
+		{name: "tooManyRequests", err: ErrTooManyRequests, expectedClientError: true, expectedRetryable: true},
+		{name: "plain", err: errors.New("boom"), expectedServerError: true},
+		{name: "gatewayTimeout", err: WrapErrGatewayTimeout(errors.New("slow")), expectedServerError: true, expectedRetryable: true},
+		{name: "redirect", err: NewRedirect(http.StatusSeeOther, "/other"), expectedRedirect: true},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if IsClientError(testCase.err) != testCase.expectedClientError ||
+				IsServerError(testCase.err) != testCase.expectedServerError ||
+				IsRetryable(testCase.err) != testCase.expectedRetryable ||
+				IsRedirect(testCase.err) != testCase.expectedRedirect {
+				t.Fatalf("Bad classification of %v", testCase.err)
+			}
+		})
+	}
+
+	if !IsServerErrorResult(&Response{StatusCode: http.StatusBadGateway}) || IsClientErrorResult(&Response{StatusCode: http.StatusOK}) {
+		t.Fatalf("Bad result classification")
+	}
+}
+
+func TestErrorToProcessingResult(t *testing.T) {
+	result := ErrorToProcessingResult(NewErrTooManyRequestsRetryAfter(0))
+	if result.GetStatusCode() != http.StatusTooManyRequests ||
+		result.GetContentType() != ContentTypeText ||
+		result.GetHeaders()["Retry-After"] != "0" ||
+		string(result.GetBody().([]byte)) != "Too Many Requests" {
+		t.Fatalf("Bad result: %+v", result)
+	}
+
+	// returned problems are sent as text, like the processor sends them
+	problemError := NewProblemError(http.StatusBadRequest, "bad")
+
+	result = ErrorToProcessingResult(problemError)
+	if result.GetStatusCode() != http.StatusBadRequest ||
+		result.GetContentType() != ContentTypeText ||
+		string(result.GetBody().([]byte)) != problemError.Error() {
+		t.Fatalf("Problems should render as text, got %s", result.GetContentType())
+	}
+}
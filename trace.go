@@ -0,0 +1,276 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Trace propagation headers
+const (
+	TraceParentHeader  = "traceparent"
+	TraceStateHeader   = "tracestate"
+	B3Header           = "b3"
+	B3TraceIDHeader    = "X-B3-TraceId"
+	B3SpanIDHeader     = "X-B3-SpanId"
+	B3SampledHeader    = "X-B3-Sampled"
+	B3ParentSpanHeader = "X-B3-ParentSpanId"
+)
+
+// TracePropagation selects the header formats in which trace contexts are propagated
+type TracePropagation int
+
+const (
+
+	// TracePropagationW3C propagates the trace context as W3C traceparent / tracestate headers
+	TracePropagationW3C TracePropagation = 1 << iota
+
+	// TracePropagationB3 propagates the trace context as B3 multi headers
+	TracePropagationB3
+)
+
+// ErrInvalidTraceParent is returned when a traceparent header cannot be parsed
+var ErrInvalidTraceParent = errors.New("Invalid traceparent")
+
+var emptyTraceID = strings.Repeat("0", 32)
+var emptySpanID = strings.Repeat("0", 16)
+
+type traceContextKey struct{}
+
+// TraceContext identifies the span that handles an event as part of a distributed trace
+type TraceContext struct {
+
+	// TraceID is the 32 hex character ID of the trace
+	TraceID string
+
+	// SpanID is the 16 hex character ID of the span
+	SpanID string
+
+	// ParentSpanID is the ID of the parent span, if known
+	ParentSpanID string
+
+	// Sampled is set if the trace is sampled
+	Sampled bool
+
+	// TraceState holds vendor specific trace information, propagated as is
+	TraceState string
+}
+
+// NewTraceContext starts a new sampled trace
+func NewTraceContext() *TraceContext {
+	return &TraceContext{
+		TraceID: newTraceID(32),
+		SpanID:  newTraceID(16),
+		Sampled: true,
+	}
+}
+
+// NewChild returns a trace context for a span whose parent is this span
+func (tc *TraceContext) NewChild() *TraceContext {
+	return &TraceContext{
+		TraceID:      tc.TraceID,
+		SpanID:       newTraceID(16),
+		ParentSpanID: tc.SpanID,
+		Sampled:      tc.Sampled,
+		TraceState:   tc.TraceState,
+	}
+}
+
+// TraceParent returns the trace context formatted as a W3C traceparent header
+func (tc *TraceContext) TraceParent() string {
+	flags := "00"
+	if tc.Sampled {
+		flags = "01"
+	}
+
+	return fmt.Sprintf("00-%s-%s-%s", tc.TraceID, tc.SpanID, flags)
+}
+
+// ParseTraceParent parses a W3C traceparent header
+func ParseTraceParent(traceParent string) (*TraceContext, error) {
+	parts := strings.Split(strings.TrimSpace(traceParent), "-")
+	if len(parts) < 4 {
+		return nil, ErrInvalidTraceParent
+	}
+
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+
+	// version ff is forbidden, and version 00 has exactly 4 parts
+	if !isHex(version, 2) || version == "ff" || (version == "00" && len(parts) != 4) {
+		return nil, ErrInvalidTraceParent
+	}
+
+	if !isHex(traceID, 32) || traceID == emptyTraceID || !isHex(spanID, 16) || spanID == emptySpanID {
+		return nil, ErrInvalidTraceParent
+	}
+
+	flagBytes, err := hex.DecodeString(flags)
+	if err != nil || len(flagBytes) != 1 {
+		return nil, ErrInvalidTraceParent
+	}
+
+	return &TraceContext{
+		TraceID: traceID,
+		SpanID:  spanID,
+		Sampled: flagBytes[0]&0x01 == 0x01,
+	}, nil
+}
+
+// ExtractTraceContext extracts the trace context from the headers of an event, trying W3C headers
+// first and B3 headers next. Returns nil if the event carries no valid trace context
+func ExtractTraceContext(event Event) *TraceContext {
+	if traceParent := getEventHeaderString(event, TraceParentHeader); traceParent != "" {
+		if traceContext, err := ParseTraceParent(traceParent); err == nil {
+			traceContext.TraceState = getEventHeaderString(event, TraceStateHeader)
+			return traceContext
+		}
+	}
+
+	if b3 := getEventHeaderString(event, B3Header); b3 != "" {
+		if traceContext := parseB3Single(b3); traceContext != nil {
+			return traceContext
+		}
+	}
+
+	return parseB3Multi(getEventHeaderString(event, B3TraceIDHeader),
+		getEventHeaderString(event, B3SpanIDHeader),
+		getEventHeaderString(event, B3SampledHeader),
+		getEventHeaderString(event, B3ParentSpanHeader))
+}
+
+// ContextWithTraceContext returns a copy of the context carrying the trace context. Functions called
+// through the platform with the returned context become children of this trace context
+func ContextWithTraceContext(ctx context.Context, traceContext *TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, traceContext)
+}
+
+// TraceContextFromContext returns the trace context carried by the context, if any
+func TraceContextFromContext(ctx context.Context) *TraceContext {
+	traceContext, _ := ctx.Value(traceContextKey{}).(*TraceContext)
+	return traceContext
+}
+
+// injectTraceContext sets the headers of the trace context in the given propagation formats
+func injectTraceContext(traceContext *TraceContext, propagation TracePropagation, setHeader func(string, string)) {
+	if propagation == 0 {
+		propagation = TracePropagationW3C
+	}
+
+	if propagation&TracePropagationW3C != 0 {
+		setHeader(TraceParentHeader, traceContext.TraceParent())
+
+		if traceContext.TraceState != "" {
+			setHeader(TraceStateHeader, traceContext.TraceState)
+		}
+	}
+
+	if propagation&TracePropagationB3 != 0 {
+		setHeader(B3TraceIDHeader, traceContext.TraceID)
+		setHeader(B3SpanIDHeader, traceContext.SpanID)
+
+		if traceContext.ParentSpanID != "" {
+			setHeader(B3ParentSpanHeader, traceContext.ParentSpanID)
+		}
+
+		if traceContext.Sampled {
+			setHeader(B3SampledHeader, "1")
+		} else {
+			setHeader(B3SampledHeader, "0")
+		}
+	}
+}
+
+// parseB3Single parses a b3 header of the form {TraceId}-{SpanId}-{SamplingState}-{ParentSpanId}
+func parseB3Single(b3 string) *TraceContext {
+	parts := strings.Split(strings.TrimSpace(b3), "-")
+	if len(parts) < 2 {
+		return nil
+	}
+
+	sampled, parentSpanID := "", ""
+	if len(parts) > 2 {
+		sampled = parts[2]
+	}
+
+	if len(parts) > 3 {
+		parentSpanID = parts[3]
+	}
+
+	return parseB3Multi(parts[0], parts[1], sampled, parentSpanID)
+}
+
+func parseB3Multi(traceID string, spanID string, sampled string, parentSpanID string) *TraceContext {
+
+	traceID, spanID = strings.ToLower(traceID), strings.ToLower(spanID)
+
+	// 64 bit trace IDs are left padded to 128 bits
+	if isHex(traceID, 16) {
+		traceID = strings.Repeat("0", 16) + traceID
+	}
+	if !isHex(traceID, 32) || traceID == emptyTraceID || !isHex(spanID, 16) || spanID == emptySpanID {
+		return nil
+	}
+
+	parentSpanID = strings.ToLower(parentSpanID)
+	if !isHex(parentSpanID, 16) {
+		parentSpanID = ""
+	}
+
+	return &TraceContext{
+		TraceID:      traceID,
+		SpanID:       spanID,
+		ParentSpanID: parentSpanID,
+		Sampled:      sampled == "1" || sampled == "d" || strings.EqualFold(sampled, "true"),
+	}
+}
+
+func getEventHeaderString(event Event, key string) string {
+	if value, _ := convertToString(event.GetHeader(key)); value != "" {
+		return value
+	}
+
+	// events whose GetHeader is case sensitive are searched through all their headers
+	return Headers(event.GetHeaders()).GetString(key)
+}
+
+func isHex(value string, length int) bool {
+	if len(value) != length {
+		return false
+	}
+
+	for _, r := range value {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newTraceID(length int) string {
+	id := make([]byte, length/2)
+
+	// crypto/rand does not fail on supported platforms
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestExtractTraceContext(t *testing.T) {
+	for _, testCase := range []struct {
+		name            string
+		headers         map[string]interface{}
+		expectedTraceID string
+		expectedSpanID  string
+		expectedSampled bool
+	}{
+		{
+			name: "w3c",
+			headers: map[string]interface{}{
+				"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			},
+			expectedTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			expectedSpanID:  "00f067aa0ba902b7",
+			expectedSampled: true,
+		},
+		{
+			name: "b3Single",
+			headers: map[string]interface{}{
+				"b3": "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-0",
+			},
+			expectedTraceID: "80f198ee56343ba864fe8b2a57d3eff7",
+			expectedSpanID:  "e457b5a2e4d86bd1",
+		},
+		{
+			name: "b3Multi",
+			headers: map[string]interface{}{
+				"X-B3-TraceId": "A3CE929D0E0E4736",
+				"X-B3-SpanId":  "00F067AA0BA902B7",
+				"X-B3-Sampled": "1",
+			},
+			expectedTraceID: "0000000000000000a3ce929d0e0e4736",
+			expectedSpanID:  "00f067aa0ba902b7",
+			expectedSampled: true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			traceContext := ExtractTraceContext(&MemoryEvent{Headers: testCase.headers})
+			if traceContext == nil {
+				t.Fatalf("No trace context extracted")
+			}
+
+			if traceContext.TraceID != testCase.expectedTraceID ||
+				traceContext.SpanID != testCase.expectedSpanID ||
+				traceContext.Sampled != testCase.expectedSampled {
+				t.Fatalf("Bad trace context: %+v", traceContext)
+			}
+		})
+	}
+
+	if ExtractTraceContext(&MemoryEvent{Headers: map[string]interface{}{"traceparent": "garbage"}}) != nil {
+		t.Fatalf("Expected invalid traceparent to be ignored")
+	}
+}
+
+func TestTraceContextPropagation(t *testing.T) {
+	traceContext := NewTraceContext()
+	child := traceContext.NewChild()
+
+	if child.TraceID != traceContext.TraceID || child.ParentSpanID != traceContext.SpanID {
+		t.Fatalf("Child is not part of the trace: %+v", child)
+	}
+
+	parsedTraceContext, err := ParseTraceParent(child.TraceParent())
+	if err != nil {
+		t.Fatalf("Failed to parse traceparent: %s", err)
+	}
+
+	if parsedTraceContext.TraceID != child.TraceID || parsedTraceContext.SpanID != child.SpanID {
+		t.Fatalf("Bad round trip: %+v != %+v", parsedTraceContext, child)
+	}
+
+	ctx := ContextWithTraceContext(context.Background(), traceContext)
+	if TraceContextFromContext(ctx) != traceContext {
+		t.Fatalf("Trace context not carried by context")
+	}
+}
+
+func TestPlatformForEvent(t *testing.T) {
+	traceParents := make(chan string, 3)
+
+	address := startFunctionServer(t, func(ctx *fasthttp.RequestCtx) {
+		traceParents <- string(ctx.Request.Header.Peek("traceparent"))
+	})
+
+	nuclioContext := &Context{
+		Platform: newTestPlatform(t, &PlatformConfiguration{
+			FunctionResolver: StaticFunctionResolver{"callee": address},
+		}),
+	}
+
+	// each event gets a platform bound to its own trace, even when handled by the same context
+	for _, traceID := range []string{
+		"4bf92f3577b34da6a3ce929d0e0e4736",
+		"80f198ee56343ba864fe8b2a57d3eff7",
+	} {
+		event := NewMemoryEvent().WithHeader("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+
+		if _, err := nuclioContext.PlatformForEvent(event).CallFunction("callee", NewMemoryEvent()); err != nil {
+			t.Fatalf("Call failed: %s", err)
+		}
+
+		traceContext, err := ParseTraceParent(<-traceParents)
+		if err != nil {
+			t.Fatalf("Failed to parse propagated traceparent: %s", err)
+		}
+
+		if traceContext.TraceID != traceID || traceContext.SpanID == "00f067aa0ba902b7" {
+			t.Fatalf("Called function is not a child of the event trace: %+v", traceContext)
+		}
+	}
+
+	// the platform of the context itself isn't bound to any of the handled events
+	if _, err := nuclioContext.Platform.CallFunction("callee", NewMemoryEvent()); err != nil {
+		t.Fatalf("Call failed: %s", err)
+	}
+
+	if traceParent := <-traceParents; traceParent != "" {
+		t.Fatalf("Unexpected traceparent: %s", traceParent)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"net"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Transport sends requests to functions on behalf of the platform. It can be replaced (e.g. in tests)
+// through PlatformConfiguration
+type Transport interface {
+
+	// Do sends the request and fills the response, giving up at the deadline unless it is zero. If the
+	// response has StreamBody set, the body should be made available through its body stream
+	Do(request *fasthttp.Request, response *fasthttp.Response, deadline time.Time) error
+}
+
+// ClientTransport is a Transport sending requests over the network through a fasthttp client
+type ClientTransport struct {
+	Client *fasthttp.Client
+}
+
+// NewClientTransport creates a transport whose client applies the timeouts of the configuration
+func NewClientTransport(configuration *PlatformConfiguration) *ClientTransport {
+	client := &fasthttp.Client{
+		ReadTimeout:  configuration.ReadTimeout,
+		WriteTimeout: configuration.WriteTimeout,
+	}
+
+	if configuration.ConnectTimeout > 0 {
+		connectTimeout := configuration.ConnectTimeout
+		client.Dial = func(addr string) (net.Conn, error) {
+			return fasthttp.DialTimeout(addr, connectTimeout)
+		}
+	}
+
+	return &ClientTransport{
+		Client: client,
+	}
+}
+
+// Do sends the request and fills the response
+func (ct *ClientTransport) Do(request *fasthttp.Request, response *fasthttp.Response, deadline time.Time) error {
+	if deadline.IsZero() {
+		return ct.Client.Do(request, response)
+	}
+
+	return ct.Client.DoDeadline(request, response, deadline)
+}
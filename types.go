@@ -18,3 +18,28 @@ package nuclio
 
 // ID is event ID
 type ID string
+
+// Handler is the signature of a function handler
+type Handler func(context *Context, event Event) (interface{}, error)
+
+// TriggerInfo is a TriggerInfoProvider holding fixed values
+type TriggerInfo struct {
+	Class string `json:"class,omitempty"`
+	Kind  string `json:"kind,omitempty"`
+	Name  string `json:"name,omitempty"`
+}
+
+// GetClass gets the class of source (sync, async, etc)
+func (ti *TriggerInfo) GetClass() string {
+	return ti.Class
+}
+
+// GetKind gets specific kind of source (http, rabbit mq, etc)
+func (ti *TriggerInfo) GetKind() string {
+	return ti.Kind
+}
+
+// GetName get given name of trigger
+func (ti *TriggerInfo) GetName() string {
+	return ti.Name
+}
@@ -0,0 +1,629 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schema is the subset of JSON Schema used to validate event bodies. Type is one of "object",
+// "array", "string", "number", "integer", "boolean" or "null", and an empty Type accepts any value
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// compiledPatterns caches the compiled patterns of schemas by their source, so that validating never
+// modifies schemas, which may be shared by handlers running concurrently
+var compiledPatterns sync.Map
+
+// ValidationRules declares what a valid event looks like
+type ValidationRules struct {
+
+	// Schema validates the decoded body of the event. If nil, the body is not validated
+	Schema *Schema
+
+	// RequiredHeaders are headers which must be present and non empty
+	RequiredHeaders []string
+
+	// RequiredFields are event fields which must be present and non empty
+	RequiredFields []string
+
+	// PathPattern is a pattern like /users/{id} which the path of the event must match, capturing
+	// non empty path parameters
+	PathPattern string
+
+	// DecodeOptions controls how the body is decoded before it is validated against the schema
+	DecodeOptions *DecodeOptions
+}
+
+// Validator validates events against declarative rules. Violations of the rules are reported
+// together in a single ProblemError listing every invalid header, field, path parameter and body
+// member. Missing headers, fields or path parameters and malformed bodies are reported with status
+// 400 (ErrBadRequest), and bodies violating the schema with status 422 (ErrUnprocessableEntity)
+type Validator struct {
+	rules       ValidationRules
+	pathPattern *pathPattern
+}
+
+// NewValidator creates a validator for the rules, failing if the rules are invalid
+func NewValidator(rules *ValidationRules) (*Validator, error) {
+	newValidator := &Validator{
+		rules: *rules,
+	}
+
+	if rules.Schema != nil {
+		if err := rules.Schema.compile(); err != nil {
+			return nil, fmt.Errorf("Invalid schema: %w", err)
+		}
+	}
+
+	if rules.PathPattern != "" {
+		newValidator.pathPattern = newPathPattern(rules.PathPattern)
+	}
+
+	return newValidator, nil
+}
+
+// Validate validates the event, returning nil if it is valid, a *ProblemError listing the violations
+// if it isn't, or the error with status code returned by DecodeBody if the body can't be decoded
+func (v *Validator) Validate(event Event) error {
+	var fieldErrors []FieldError
+	statusCode := http.StatusUnprocessableEntity
+
+	addRequestError := func(field string, code string, message string) {
+		fieldErrors = append(fieldErrors, FieldError{Field: field, Message: message, Code: code})
+		statusCode = http.StatusBadRequest
+	}
+
+	for _, headerName := range v.rules.RequiredHeaders {
+		if getEventHeaderString(event, headerName) == "" {
+			addRequestError("headers."+headerName, "required", "Header is required")
+		}
+	}
+
+	for _, fieldName := range v.rules.RequiredFields {
+		if isEmptyValue(event.GetField(fieldName)) {
+			addRequestError("fields."+fieldName, "required", "Field is required")
+		}
+	}
+
+	if v.pathPattern != nil {
+		if _, matched := v.pathPattern.match(event.GetPath()); !matched {
+			addRequestError("path", "pattern", fmt.Sprintf("Path does not match %s", v.rules.PathPattern))
+		}
+	}
+
+	if v.rules.Schema != nil {
+		if len(event.GetBody()) == 0 {
+			addRequestError("body", "required", "Body is required")
+		} else {
+			body, err := DecodeBodyWithOptions[interface{}](event, v.rules.DecodeOptions)
+			switch {
+			case err == nil:
+				fieldErrors = v.rules.Schema.validate("body", body, fieldErrors)
+			case StatusCodeOf(err) == http.StatusBadRequest:
+				addRequestError("body", "decode", err.Error())
+			default:
+				return err
+			}
+		}
+	}
+
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+
+	problem := NewProblemError(statusCode, fmt.Sprintf("Event failed validation with %d violation(s)", len(fieldErrors)))
+	problem.FieldErrors = fieldErrors
+
+	return problem
+}
+
+// Wrap returns a handler which validates events before passing them to the handler. Invalid events
+// are not passed to the handler, and are responded to with a problem details document
+func (v *Validator) Wrap(handler Handler) Handler {
+	return func(context *Context, event Event) (interface{}, error) {
+		if err := v.Validate(event); err != nil {
+			return ProblemFromError(err).Response(), nil
+		}
+
+		return handler(context, event)
+	}
+}
+
+// Validate validates a decoded value (e.g. the result of DecodeBody[interface{}]) against the schema,
+// returning the violations. Fields are named by their path in the value (e.g. items[0].name)
+func (s *Schema) Validate(value interface{}) ([]FieldError, error) {
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+
+	return s.validate("", value, nil), nil
+}
+
+// SchemaFor derives a schema from the type of the value, naming struct fields by their json tags.
+// Constraints are declared in a "validate" tag holding a comma separated list of:
+//
+//	required        the field must be present
+//	min=N, max=N    bounds of numbers, or of the length of strings and slices
+//	enum=a|b|c      the allowed values
+//	pattern=RE      a regular expression strings must match (must be last, as it may contain commas)
+func SchemaFor(value interface{}) (*Schema, error) {
+	valueType := reflect.TypeOf(value)
+	if valueType == nil {
+		return &Schema{}, nil
+	}
+
+	schema, err := schemaForType(valueType, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := schema.compile(); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// compile compiles the patterns of the schema and its subschemas, failing if any is invalid
+func (s *Schema) compile() error {
+	if s.Pattern != "" {
+		if _, err := compilePattern(s.Pattern); err != nil {
+			return fmt.Errorf("Invalid pattern %q: %w", s.Pattern, err)
+		}
+	}
+
+	for propertyName, property := range s.Properties {
+		if err := property.compile(); err != nil {
+			return fmt.Errorf("Invalid property %s: %w", propertyName, err)
+		}
+	}
+
+	if s.Items != nil {
+		if err := s.Items.compile(); err != nil {
+			return fmt.Errorf("Invalid items: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if compiledPattern, found := compiledPatterns.Load(pattern); found {
+		return compiledPattern.(*regexp.Regexp), nil
+	}
+
+	compiledPattern, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	compiledPatterns.Store(pattern, compiledPattern)
+	return compiledPattern, nil
+}
+
+func (s *Schema) validate(path string, value interface{}, fieldErrors []FieldError) []FieldError {
+	addError := func(code string, format string, args ...interface{}) {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   path,
+			Message: fmt.Sprintf(format, args...),
+			Code:    code,
+		})
+	}
+
+	if s.Type != "" && !isSchemaType(s.Type, value) {
+		addError("type", "Expected %s", s.Type)
+		return fieldErrors
+	}
+
+	if len(s.Enum) > 0 && !isEnumValue(s.Enum, value) {
+		addError("enum", "Must be one of %v", s.Enum)
+	}
+
+	if number, isNumber := toFloat64(value); isNumber {
+		if s.Minimum != nil && number < *s.Minimum {
+			addError("minimum", "Must be at least %v", *s.Minimum)
+		}
+
+		if s.Maximum != nil && number > *s.Maximum {
+			addError("maximum", "Must be at most %v", *s.Maximum)
+		}
+	}
+
+	switch typedValue := value.(type) {
+	case string:
+		length := len([]rune(typedValue))
+
+		if s.MinLength != nil && length < *s.MinLength {
+			addError("minLength", "Must be at least %d characters long", *s.MinLength)
+		}
+
+		if s.MaxLength != nil && length > *s.MaxLength {
+			addError("maxLength", "Must be at most %d characters long", *s.MaxLength)
+		}
+
+		if s.Pattern != "" {
+
+			// the schema was compiled before validating, so the pattern is valid
+			compiledPattern, _ := compilePattern(s.Pattern)
+			if !compiledPattern.MatchString(typedValue) {
+				addError("pattern", "Must match %s", s.Pattern)
+			}
+		}
+
+	case []interface{}:
+		if s.MinItems != nil && len(typedValue) < *s.MinItems {
+			addError("minItems", "Must have at least %d items", *s.MinItems)
+		}
+
+		if s.MaxItems != nil && len(typedValue) > *s.MaxItems {
+			addError("maxItems", "Must have at most %d items", *s.MaxItems)
+		}
+
+		if s.Items != nil {
+			for itemIndex, item := range typedValue {
+				fieldErrors = s.Items.validate(fmt.Sprintf("%s[%d]", path, itemIndex), item, fieldErrors)
+			}
+		}
+
+	case map[string]interface{}:
+		for _, propertyName := range s.Required {
+			if _, found := typedValue[propertyName]; !found {
+				fieldErrors = append(fieldErrors, FieldError{
+					Field:   joinFieldPath(path, propertyName),
+					Message: "Field is required",
+					Code:    "required",
+				})
+			}
+		}
+
+		// iterate in a stable order so that violations are reported consistently
+		propertyNames := make([]string, 0, len(typedValue))
+		for propertyName := range typedValue {
+			propertyNames = append(propertyNames, propertyName)
+		}
+
+		sort.Strings(propertyNames)
+
+		for _, propertyName := range propertyNames {
+			propertyPath := joinFieldPath(path, propertyName)
+
+			if property, found := s.Properties[propertyName]; found {
+				fieldErrors = property.validate(propertyPath, typedValue[propertyName], fieldErrors)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				fieldErrors = append(fieldErrors, FieldError{
+					Field:   propertyPath,
+					Message: "Unknown field",
+					Code:    "additionalProperties",
+				})
+			}
+		}
+	}
+
+	return fieldErrors
+}
+
+func schemaForType(valueType reflect.Type, visiting map[reflect.Type]bool) (*Schema, error) {
+	for valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+
+	if valueType == reflect.TypeOf(time.Time{}) {
+		return &Schema{Type: "string"}, nil
+	}
+
+	switch valueType.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.Slice, reflect.Array:
+
+		// byte slices are encoded as (base64) strings
+		if valueType.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string"}, nil
+		}
+
+		itemsSchema, err := schemaForType(valueType.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Schema{Type: "array", Items: itemsSchema}, nil
+	case reflect.Map:
+		return &Schema{Type: "object"}, nil
+	case reflect.Struct:
+
+		// recursive types are validated up to the point of recursion
+		if visiting[valueType] {
+			return &Schema{}, nil
+		}
+
+		visiting[valueType] = true
+		defer delete(visiting, valueType)
+
+		schema := &Schema{
+			Type:       "object",
+			Properties: map[string]*Schema{},
+		}
+
+		if err := addStructProperties(schema, valueType, visiting); err != nil {
+			return nil, err
+		}
+
+		return schema, nil
+	}
+
+	return &Schema{}, nil
+}
+
+func addStructProperties(schema *Schema, structType reflect.Type, visiting map[reflect.Type]bool) error {
+	for fieldIndex := 0; fieldIndex < structType.NumField(); fieldIndex++ {
+		field := structType.Field(fieldIndex)
+
+		jsonTag := strings.Split(field.Tag.Get("json"), ",")
+		if jsonTag[0] == "-" {
+			continue
+		}
+
+		// fields of embedded structs are promoted, as they are by encoding/json
+		if field.Anonymous && jsonTag[0] == "" {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+
+			if embeddedType.Kind() == reflect.Struct {
+				if err := addStructProperties(schema, embeddedType, visiting); err != nil {
+					return err
+				}
+
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		propertyName := jsonTag[0]
+		if propertyName == "" {
+			propertyName = field.Name
+		}
+
+		propertySchema, err := schemaForType(field.Type, visiting)
+		if err != nil {
+			return err
+		}
+
+		required, err := applyValidateTag(propertySchema, field.Tag.Get("validate"))
+		if err != nil {
+			return fmt.Errorf("Invalid validate tag of field %s: %w", field.Name, err)
+		}
+
+		if required {
+			schema.Required = append(schema.Required, propertyName)
+		}
+
+		schema.Properties[propertyName] = propertySchema
+	}
+
+	return nil
+}
+
+// applyValidateTag applies the constraints of a validate tag to the schema, returning whether the
+// field is required
+func applyValidateTag(schema *Schema, tag string) (bool, error) {
+	required := false
+
+	for tag != "" {
+		var constraint string
+
+		// the pattern may contain commas, and takes the rest of the tag
+		if strings.HasPrefix(tag, "pattern=") {
+			constraint, tag = tag, ""
+		} else {
+			constraint, tag, _ = strings.Cut(tag, ",")
+		}
+
+		name, value, _ := strings.Cut(constraint, "=")
+
+		switch name {
+		case "required":
+			required = true
+		case "min", "max":
+			if err := applyBoundConstraint(schema, name, value); err != nil {
+				return false, err
+			}
+		case "enum":
+			for _, enumValue := range strings.Split(value, "|") {
+				schema.Enum = append(schema.Enum, parseEnumValue(schema.Type, enumValue))
+			}
+		case "pattern":
+			schema.Pattern = value
+		case "":
+		default:
+			return false, fmt.Errorf("Unknown constraint %q", name)
+		}
+	}
+
+	return required, nil
+}
+
+func applyBoundConstraint(schema *Schema, name string, value string) error {
+	switch schema.Type {
+	case "integer", "number":
+		bound, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("Invalid %s %q: %w", name, value, err)
+		}
+
+		if name == "min" {
+			schema.Minimum = &bound
+		} else {
+			schema.Maximum = &bound
+		}
+
+	case "string", "array":
+		bound, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("Invalid %s %q: %w", name, value, err)
+		}
+
+		switch {
+		case schema.Type == "string" && name == "min":
+			schema.MinLength = &bound
+		case schema.Type == "string":
+			schema.MaxLength = &bound
+		case name == "min":
+			schema.MinItems = &bound
+		default:
+			schema.MaxItems = &bound
+		}
+
+	default:
+		return fmt.Errorf("Constraint %s is not supported for type %q", name, schema.Type)
+	}
+
+	return nil
+}
+
+func parseEnumValue(schemaType string, value string) interface{} {
+	switch schemaType {
+	case "integer", "number":
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	case "boolean":
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean
+		}
+	}
+
+	return value
+}
+
+func isSchemaType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "object":
+		_, isObject := value.(map[string]interface{})
+		return isObject
+	case "array":
+		_, isArray := value.([]interface{})
+		return isArray
+	case "string":
+		_, isString := value.(string)
+		return isString
+	case "boolean":
+		_, isBool := value.(bool)
+		return isBool
+	case "null":
+		return value == nil
+	case "number":
+		_, isNumber := toFloat64(value)
+		return isNumber
+	case "integer":
+		number, isNumber := toFloat64(value)
+		return isNumber && number == math.Trunc(number)
+	}
+
+	return false
+}
+
+func isEnumValue(enum []interface{}, value interface{}) bool {
+	number, isNumber := toFloat64(value)
+
+	for _, enumValue := range enum {
+		if enumNumber, isEnumNumber := toFloat64(enumValue); isNumber && isEnumNumber {
+			if number == enumNumber {
+				return true
+			}
+
+			continue
+		}
+
+		if reflect.DeepEqual(enumValue, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toFloat64 converts numbers of any type (as decoded from JSON or msgpack) to float64
+func toFloat64(value interface{}) (float64, bool) {
+	reflectValue := reflect.ValueOf(value)
+
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(reflectValue.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(reflectValue.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return reflectValue.Float(), true
+	}
+
+	return 0, false
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch typedValue := value.(type) {
+	case nil:
+		return true
+	case string:
+		return typedValue == ""
+	case []byte:
+		return len(typedValue) == 0
+	}
+
+	return false
+}
+
+func joinFieldPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
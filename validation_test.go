@@ -0,0 +1,233 @@
+/*
+Copyright 2017 The Nuclio Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nuclio
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type validatedOrder struct {
+	ID       string   `json:"id" validate:"required,pattern=^o[0-9]+$"`
+	Quantity int      `json:"quantity" validate:"required,min=1,max=10"`
+	Priority string   `json:"priority,omitempty" validate:"enum=low|high"`
+	Tags     []string `json:"tags,omitempty" validate:"max=2"`
+}
+
+func TestValidatorSchema(t *testing.T) {
+	schema := &Schema{}
+	if err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"required": ["name"],
+		"additionalProperties": false,
+		"properties": {
+			"name": {"type": "string", "minLength": 2},
+			"items": {"type": "array", "items": {"type": "integer", "minimum": 0}}
+		}
+	}`), schema); err != nil {
+		t.Fatalf("Failed to parse schema: %s", err)
+	}
+
+	validator, err := NewValidator(&ValidationRules{Schema: schema})
+	if err != nil {
+		t.Fatalf("Failed to create validator: %s", err)
+	}
+
+	if err := validator.Validate(&MemoryEvent{
+		ContentType: ContentTypeJSON,
+		Body:        []byte(`{"name": "ab", "items": [1, 2]}`),
+	}); err != nil {
+		t.Fatalf("Valid event failed validation: %s", err)
+	}
+
+	err = validator.Validate(&MemoryEvent{
+		ContentType: ContentTypeJSON,
+		Body:        []byte(`{"items": [1, -1, 1.5], "extra": true}`),
+	})
+
+	var problem *ProblemError
+	if !errors.As(err, &problem) || !errors.Is(err, ErrUnprocessableEntity) {
+		t.Fatalf("Expected unprocessable entity problem, got %v", err)
+	}
+
+	expectedFieldErrors := []FieldError{
+		{Field: "body.name", Message: "Field is required", Code: "required"},
+		{Field: "body.extra", Message: "Unknown field", Code: "additionalProperties"},
+		{Field: "body.items[1]", Message: "Must be at least 0", Code: "minimum"},
+		{Field: "body.items[2]", Message: "Expected integer", Code: "type"},
+	}
+
+	if !reflect.DeepEqual(problem.FieldErrors, expectedFieldErrors) {
+		t.Fatalf("Bad field errors: %+v", problem.FieldErrors)
+	}
+
+	// malformed bodies are bad requests
+	err = validator.Validate(&MemoryEvent{ContentType: ContentTypeJSON, Body: []byte(`{`)})
+	if !errors.Is(err, ErrBadRequest) {
+		t.Fatalf("Expected bad request for malformed body, got %v", err)
+	}
+}
+
+func TestValidatorRequestRules(t *testing.T) {
+	validator, err := NewValidator(&ValidationRules{
+		RequiredHeaders: []string{"X-Api-Key"},
+		PathPattern:     "/orders/{id}",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create validator: %s", err)
+	}
+
+	if err := validator.Validate(&MemoryEvent{
+		Path:    "/orders/o1",
+		Headers: map[string]interface{}{"X-Api-Key": "secret"},
+	}); err != nil {
+		t.Fatalf("Valid event failed validation: %s", err)
+	}
+
+	err = validator.Validate(&MemoryEvent{Path: "/orders/"})
+
+	var problem *ProblemError
+	if !errors.As(err, &problem) || problem.StatusCode() != http.StatusBadRequest || len(problem.FieldErrors) != 2 {
+		t.Fatalf("Expected bad request with two violations, got %v", err)
+	}
+}
+
+func TestValidatorWrap(t *testing.T) {
+	schema, err := SchemaFor(validatedOrder{})
+	if err != nil {
+		t.Fatalf("Failed to derive schema: %s", err)
+	}
+
+	validator, err := NewValidator(&ValidationRules{Schema: schema})
+	if err != nil {
+		t.Fatalf("Failed to create validator: %s", err)
+	}
+
+	handlerCalled := false
+	handler := validator.Wrap(func(context *Context, event Event) (interface{}, error) {
+		handlerCalled = true
+		return "ok", nil
+	})
+
+	result, err := handler(nil, &MemoryEvent{
+		ContentType: ContentTypeJSON,
+		Body:        []byte(`{"id": "x1", "quantity": 11, "priority": "medium", "tags": ["a", "b", "c"]}`),
+	})
+	if err != nil || handlerCalled {
+		t.Fatalf("Invalid event should not reach the handler")
+	}
+
+	response := result.(Response)
+	if response.StatusCode != http.StatusUnprocessableEntity || response.ContentType != ContentTypeProblemJSON {
+		t.Fatalf("Bad response: %d %s", response.StatusCode, response.ContentType)
+	}
+
+	document := struct {
+		Errors []FieldError `json:"errors"`
+	}{}
+
+	if err := json.Unmarshal(response.Body, &document); err != nil {
+		t.Fatalf("Failed to decode problem document: %s", err)
+	}
+
+	var fields []string
+	for _, fieldError := range document.Errors {
+		fields = append(fields, fieldError.Field+":"+fieldError.Code)
+	}
+
+	expectedFields := []string{"body.id:pattern", "body.priority:enum", "body.quantity:maximum", "body.tags:maxItems"}
+	if !reflect.DeepEqual(fields, expectedFields) {
+		t.Fatalf("Bad field errors: %v", fields)
+	}
+
+	if _, err := handler(nil, &MemoryEvent{
+		ContentType: ContentTypeJSON,
+		Body:        []byte(`{"id": "o1", "quantity": 1}`),
+	}); err != nil || !handlerCalled {
+		t.Fatalf("Valid event should reach the handler")
+	}
+}
+
+func TestSchemaForInvalidTag(t *testing.T) {
+	type invalid struct {
+		Enabled bool `json:"enabled" validate:"min=1"`
+	}
+
+	if _, err := SchemaFor(invalid{}); err == nil {
+		t.Fatalf("Expected error for min constraint on a boolean")
+	}
+}
+
+func TestSchemaValidateConcurrently(t *testing.T) {
+	schema := &Schema{
+		Type:  "array",
+		Items: &Schema{Type: "string", Pattern: "^o[0-9]+$"},
+	}
+
+	// schemas are shared by handlers running concurrently, so validating must not modify them
+	var waitGroup sync.WaitGroup
+	for workerIndex := 0; workerIndex < 8; workerIndex++ {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			fieldErrors, err := schema.Validate([]interface{}{"o1", "x2"})
+			if err != nil || len(fieldErrors) != 1 || fieldErrors[0].Field != "[1]" {
+				t.Errorf("Bad validation: %+v, %v", fieldErrors, err)
+			}
+		}()
+	}
+
+	waitGroup.Wait()
+
+	if _, err := (&Schema{Pattern: "("}).Validate("x"); err == nil {
+		t.Fatalf("Expected error for invalid pattern")
+	}
+}
+
+func TestPathPattern(t *testing.T) {
+	for _, testCase := range []struct {
+		pattern        string
+		path           string
+		expectedParams map[string]string
+	}{
+		{"/users/{id}", "/users/42", map[string]string{"id": "42"}},
+		{"/users/{id}", "/users/42/", map[string]string{"id": "42"}},
+		{"/users/{id}", "/users/a%20b?x=1", map[string]string{"id": "a b"}},
+		{"/users/{id}", "/users", nil},
+		{"/users/{id}", "/users/42/files", nil},
+		{"/users/*/files", "/users/42/files", map[string]string{}},
+		{"/files/{path...}", "/files/a/b/c", map[string]string{"path": "a/b/c"}},
+		{"/files/{path...}", "/files", map[string]string{"path": ""}},
+		{"/", "/", map[string]string{}},
+	} {
+		params, matched := newPathPattern(testCase.pattern).match(testCase.path)
+		if matched != (testCase.expectedParams != nil) || (matched && !reflect.DeepEqual(params, testCase.expectedParams)) {
+			t.Fatalf("Pattern %s on %s: expected %v, got %v (%t)",
+				testCase.pattern,
+				testCase.path,
+				testCase.expectedParams,
+				params,
+				matched)
+		}
+	}
+}